@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -21,6 +22,9 @@ import (
 	"github.com/aa12gq/content-risk-control/internal/app/service"
 )
 
+// modelServerStartupTimeout 启动时等待NLP模型加载完成的最长时间
+const modelServerStartupTimeout = 30 * time.Second
+
 func main() {
 	cfg, err := config.Load("config/config.yaml")
 	if err != nil {
@@ -33,10 +37,10 @@ func main() {
 
 	sugar.Info("Starting content risk control service...")
 
-	// 如果启用了NLP服务，启动模型服务器
-	var modelServer *service.ModelServer
+	// 如果启用了NLP服务，启动模型服务器，审核服务在进程内调用
+	var inProcessModelServer *service.ModelServer
 	if cfg.NLPService.Enabled {
-		modelServer = service.NewModelServer(
+		modelServer := service.NewModelServer(
 			sugar,
 			"config/config.yaml",
 			cfg.NLPService.ModelPath,
@@ -50,16 +54,23 @@ func main() {
 			}
 		}()
 
-		// 等待模型服务准备就绪
-		time.Sleep(3 * time.Second)
-		if !modelServer.IsReady() {
-			sugar.Warn("NLP model server is not ready yet, proceeding without it")
-		} else {
+		// 等待模型加载完成；超时后仍继续启动，加载完成前的分析请求返回错误并按降级策略处理；
+		// 加载及HTTP服务的失败在 /api/v1/health 的 model_server 中报告
+		ctx, cancel := context.WithTimeout(context.Background(), modelServerStartupTimeout)
+		err := modelServer.WaitReady(ctx)
+		cancel()
+		switch {
+		case err == nil:
 			sugar.Info("NLP model server is ready")
+		case errors.Is(err, context.DeadlineExceeded):
+			sugar.Warnf("NLP model server is not ready after %s, requests fail until the model is loaded", modelServerStartupTimeout)
+		default:
+			sugar.Warnf("NLP model server failed to load the model, proceeding without it: %v", err)
 		}
+		inProcessModelServer = modelServer
 	}
 
-	contentService, err := service.NewContentCheckService(cfg, sugar)
+	contentService, err := service.NewContentCheckService(cfg, sugar, inProcessModelServer)
 	if err != nil {
 		sugar.Fatalf("Failed to initialize content check service: %v", err)
 	}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aa12gq/content-risk-control/internal/pkg/classifier"
+)
+
+// 训练 ModelServer 使用的文本分类模型
+//
+// 训练数据为JSONL，每行一条样本，labels 为任务名到标签的映射，可只标注部分任务：
+//
+//	{"text": "你这个废物", "labels": {"toxicity": "insult", "intent": "insult", "sentiment": "negative"}}
+//
+// toxicity 任务以 none 表示无害（必须包含，否则训练失败），sentiment 任务使用 positive、neutral、negative
+func main() {
+	data := flag.String("data", "", "训练数据文件（JSONL）")
+	out := flag.String("out", "./models/nlp_model", "模型输出路径，与 nlp_service.model_path 一致")
+	version := flag.String("version", "", "模型版本，默认为训练时间")
+	ngramMin := flag.Int("ngram-min", 1, "字符n-gram最小长度")
+	ngramMax := flag.Int("ngram-max", 3, "字符n-gram最大长度")
+	alpha := flag.Float64("alpha", 1, "加法平滑系数")
+	holdout := flag.Float64("holdout", 0.2, "用于概率校准和评估的留出比例")
+	seed := flag.Int64("seed", 1, "划分留出集的随机种子")
+	flag.Parse()
+
+	if *data == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*data)
+	if err != nil {
+		log.Fatalf("Failed to open training data: %v", err)
+	}
+	examples, err := classifier.ReadExamples(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("Failed to read training data: %v", err)
+	}
+
+	m, evaluations, err := classifier.Train(examples, classifier.TrainOptions{
+		Version:  *version,
+		NGramMin: *ngramMin,
+		NGramMax: *ngramMax,
+		Alpha:    *alpha,
+		Holdout:  *holdout,
+		Seed:     *seed,
+	})
+	if err != nil {
+		log.Fatalf("Failed to train model: %v", err)
+	}
+
+	fmt.Printf("%-12s %8s %8s %10s %10s %12s\n", "task", "train", "holdout", "accuracy", "log_loss", "temperature")
+	for _, e := range evaluations {
+		fmt.Printf("%-12s %8d %8d %10.4f %10.4f %12.4f\n", e.Task, e.Train, e.Holdout, e.Accuracy, e.LogLoss, e.Temperature)
+	}
+
+	if err := m.Save(*out); err != nil {
+		log.Fatalf("Failed to save model: %v", err)
+	}
+	fmt.Printf("model %s saved to %s (%d examples)\n", m.Version, *out, len(examples))
+}
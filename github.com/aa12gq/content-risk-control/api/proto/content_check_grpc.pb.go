@@ -240,3 +240,272 @@ var ContentCheckService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "api/proto/content_check.proto",
 }
+
+const (
+	SensitiveWordAdminService_ListWords_FullMethodName    = "/content_check.SensitiveWordAdminService/ListWords"
+	SensitiveWordAdminService_AddWords_FullMethodName     = "/content_check.SensitiveWordAdminService/AddWords"
+	SensitiveWordAdminService_RemoveWords_FullMethodName  = "/content_check.SensitiveWordAdminService/RemoveWords"
+	SensitiveWordAdminService_ImportWords_FullMethodName  = "/content_check.SensitiveWordAdminService/ImportWords"
+	SensitiveWordAdminService_ExplainCheck_FullMethodName = "/content_check.SensitiveWordAdminService/ExplainCheck"
+)
+
+// SensitiveWordAdminServiceClient is the client API for SensitiveWordAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 管理服务（敏感词管理及解释模式审核），需在metadata中携带 authorization: Bearer <token>
+type SensitiveWordAdminServiceClient interface {
+	// 分页查询敏感词
+	ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error)
+	// 添加或更新敏感词
+	AddWords(ctx context.Context, in *AddWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error)
+	// 删除敏感词
+	RemoveWords(ctx context.Context, in *RemoveWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error)
+	// 批量导入敏感词
+	ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error)
+	// 解释模式审核，返回完整的求值过程（含规则条件的实际值及检测器原始输出），可使用候选规则集试运行
+	ExplainCheck(ctx context.Context, in *ExplainCheckRequest, opts ...grpc.CallOption) (*ExplainCheckResponse, error)
+}
+
+type sensitiveWordAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensitiveWordAdminServiceClient(cc grpc.ClientConnInterface) SensitiveWordAdminServiceClient {
+	return &sensitiveWordAdminServiceClient{cc}
+}
+
+func (c *sensitiveWordAdminServiceClient) ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWordsResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordAdminService_ListWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordAdminServiceClient) AddWords(ctx context.Context, in *AddWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WordChangeResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordAdminService_AddWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordAdminServiceClient) RemoveWords(ctx context.Context, in *RemoveWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WordChangeResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordAdminService_RemoveWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordAdminServiceClient) ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*WordChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WordChangeResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordAdminService_ImportWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordAdminServiceClient) ExplainCheck(ctx context.Context, in *ExplainCheckRequest, opts ...grpc.CallOption) (*ExplainCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainCheckResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordAdminService_ExplainCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SensitiveWordAdminServiceServer is the server API for SensitiveWordAdminService service.
+// All implementations must embed UnimplementedSensitiveWordAdminServiceServer
+// for forward compatibility.
+//
+// 管理服务（敏感词管理及解释模式审核），需在metadata中携带 authorization: Bearer <token>
+type SensitiveWordAdminServiceServer interface {
+	// 分页查询敏感词
+	ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error)
+	// 添加或更新敏感词
+	AddWords(context.Context, *AddWordsRequest) (*WordChangeResponse, error)
+	// 删除敏感词
+	RemoveWords(context.Context, *RemoveWordsRequest) (*WordChangeResponse, error)
+	// 批量导入敏感词
+	ImportWords(context.Context, *ImportWordsRequest) (*WordChangeResponse, error)
+	// 解释模式审核，返回完整的求值过程（含规则条件的实际值及检测器原始输出），可使用候选规则集试运行
+	ExplainCheck(context.Context, *ExplainCheckRequest) (*ExplainCheckResponse, error)
+	mustEmbedUnimplementedSensitiveWordAdminServiceServer()
+}
+
+// UnimplementedSensitiveWordAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSensitiveWordAdminServiceServer struct{}
+
+func (UnimplementedSensitiveWordAdminServiceServer) ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWords not implemented")
+}
+func (UnimplementedSensitiveWordAdminServiceServer) AddWords(context.Context, *AddWordsRequest) (*WordChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWords not implemented")
+}
+func (UnimplementedSensitiveWordAdminServiceServer) RemoveWords(context.Context, *RemoveWordsRequest) (*WordChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWords not implemented")
+}
+func (UnimplementedSensitiveWordAdminServiceServer) ImportWords(context.Context, *ImportWordsRequest) (*WordChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportWords not implemented")
+}
+func (UnimplementedSensitiveWordAdminServiceServer) ExplainCheck(context.Context, *ExplainCheckRequest) (*ExplainCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainCheck not implemented")
+}
+func (UnimplementedSensitiveWordAdminServiceServer) mustEmbedUnimplementedSensitiveWordAdminServiceServer() {
+}
+func (UnimplementedSensitiveWordAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeSensitiveWordAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensitiveWordAdminServiceServer will
+// result in compilation errors.
+type UnsafeSensitiveWordAdminServiceServer interface {
+	mustEmbedUnimplementedSensitiveWordAdminServiceServer()
+}
+
+func RegisterSensitiveWordAdminServiceServer(s grpc.ServiceRegistrar, srv SensitiveWordAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSensitiveWordAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SensitiveWordAdminService_ServiceDesc, srv)
+}
+
+func _SensitiveWordAdminService_ListWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordAdminServiceServer).ListWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordAdminService_ListWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordAdminServiceServer).ListWords(ctx, req.(*ListWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordAdminService_AddWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordAdminServiceServer).AddWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordAdminService_AddWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordAdminServiceServer).AddWords(ctx, req.(*AddWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordAdminService_RemoveWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordAdminServiceServer).RemoveWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordAdminService_RemoveWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordAdminServiceServer).RemoveWords(ctx, req.(*RemoveWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordAdminService_ImportWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordAdminServiceServer).ImportWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordAdminService_ImportWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordAdminServiceServer).ImportWords(ctx, req.(*ImportWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordAdminService_ExplainCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordAdminServiceServer).ExplainCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordAdminService_ExplainCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordAdminServiceServer).ExplainCheck(ctx, req.(*ExplainCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SensitiveWordAdminService_ServiceDesc is the grpc.ServiceDesc for SensitiveWordAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SensitiveWordAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "content_check.SensitiveWordAdminService",
+	HandlerType: (*SensitiveWordAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWords",
+			Handler:    _SensitiveWordAdminService_ListWords_Handler,
+		},
+		{
+			MethodName: "AddWords",
+			Handler:    _SensitiveWordAdminService_AddWords_Handler,
+		},
+		{
+			MethodName: "RemoveWords",
+			Handler:    _SensitiveWordAdminService_RemoveWords_Handler,
+		},
+		{
+			MethodName: "ImportWords",
+			Handler:    _SensitiveWordAdminService_ImportWords_Handler,
+		},
+		{
+			MethodName: "ExplainCheck",
+			Handler:    _SensitiveWordAdminService_ExplainCheck_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/content_check.proto",
+}
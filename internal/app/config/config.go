@@ -15,6 +15,7 @@ type Config struct {
 	AIService    AIServiceConfig    `mapstructure:"ai_service"`
 	NLPService   NLPServiceConfig   `mapstructure:"nlp_service"`
 	RuleEngine   RuleEngineConfig   `mapstructure:"rule_engine"`
+	Admin        AdminConfig        `mapstructure:"admin"`
 }
 
 // ServerConfig 服务器配置
@@ -54,6 +55,95 @@ type ContentCheckConfig struct {
 	CacheTTL                     int  `mapstructure:"cache_ttl"`
 	BatchCheckMaxSize            int  `mapstructure:"batch_check_max_size"`
 	ContextHistorySize           int  `mapstructure:"context_history_size"`
+	PinyinMatch                  bool `mapstructure:"pinyin_match"` // 是否启用敏感词拼音、首字母及同音字匹配
+
+	Normalizer  NormalizerConfig  `mapstructure:"normalizer"`
+	WordSources WordSourcesConfig `mapstructure:"word_sources"`
+	Detection   DetectionConfig   `mapstructure:"detection"`
+
+	// Detectors 检测器实例，为空时按 use_ml_model、nlp_service 等配置创建内置检测器
+	Detectors []DetectorInstanceConfig `mapstructure:"detectors"`
+
+	// Scenes 场景策略，键为场景名，default 为未知场景及未配置项的兜底策略
+	Scenes map[string]SceneProfileConfig `mapstructure:"scenes"`
+}
+
+// DetectorInstanceConfig 检测器实例配置，同一类型可配置多个实例
+type DetectorInstanceConfig struct {
+	Type    string                 `mapstructure:"type"`    // 检测器类型: sensitive, spam, harassment, semantic, injection, llm, ensemble, model_server, nlp, semantic_nlp, ai
+	Name    string                 `mapstructure:"name"`    // 实例名，为空时同类型名，场景策略、权重及超时配置按实例名引用
+	Enabled *bool                  `mapstructure:"enabled"` // 未配置时启用
+	Scenes  []string               `mapstructure:"scenes"`  // 生效的场景，为空时所有场景生效
+	Options map[string]interface{} `mapstructure:"options"` // 检测器类型的配置项
+}
+
+// SceneProfileConfig 场景策略配置，未配置的项沿用 default 场景
+type SceneProfileConfig struct {
+	Detectors       []string                      `mapstructure:"detectors"`        // 启用的检测器，为空时启用全部检测器
+	Weights         map[string]float32            `mapstructure:"weights"`          // 风险类型 -> 分数权重，未配置的类型权重为1
+	DetectorWeights map[string]float32            `mapstructure:"detector_weights"` // 检测器 -> 分数权重，未配置的检测器权重为1
+	Aggregator      string                        `mapstructure:"aggregator"`       // 分数聚合策略: max, weighted_sum, noisy_or, risk_type_weighted
+	Thresholds      ThresholdsConfig              `mapstructure:"thresholds"`       // 判定阈值
+	RuleOverrides   map[string]RuleOverrideConfig `mapstructure:"rule_overrides"`   // 规则ID -> 覆盖项
+}
+
+// ThresholdsConfig 判定阈值，分数达到对应阈值时判定为拒绝、人工审核或警告，review或warning为0时不启用该档
+type ThresholdsConfig struct {
+	Reject  float32 `mapstructure:"reject"`
+	Review  float32 `mapstructure:"review"`
+	Warning float32 `mapstructure:"warning"`
+}
+
+// RuleOverrideConfig 场景内对规则的覆盖，未设置的项沿用规则本身的配置
+type RuleOverrideConfig struct {
+	Enabled *bool    `mapstructure:"enabled"`
+	Action  string   `mapstructure:"action"`
+	Score   *float32 `mapstructure:"score"`
+}
+
+// WordSourcesConfig 敏感词库来源配置
+type WordSourcesConfig struct {
+	MaxShrinkPercent int                `mapstructure:"max_shrink_percent"` // 单次重载允许减少的词条比例上限，超过则拒绝重载
+	Sources          []WordSourceConfig `mapstructure:"sources"`            // 为空时使用 config/sensitive_words.json
+}
+
+// WordSourceConfig 单个敏感词库来源
+type WordSourceConfig struct {
+	Type    string            `mapstructure:"type"`    // file, http, redis
+	Path    string            `mapstructure:"path"`    // file: 文件路径
+	Watch   bool              `mapstructure:"watch"`   // file: 是否监听文件变更
+	URL     string            `mapstructure:"url"`     // http: 词库地址
+	Headers map[string]string `mapstructure:"headers"` // http: 请求头
+	Timeout int               `mapstructure:"timeout"` // http: 超时时间（毫秒）
+	Key     string            `mapstructure:"key"`     // redis: 哈希键
+}
+
+// DetectionConfig 检测器执行配置，检测器并发执行
+type DetectionConfig struct {
+	Timeout          int            `mapstructure:"timeout"`           // 单次审核的检测总预算（毫秒），0表示不限制
+	DetectorTimeout  int            `mapstructure:"detector_timeout"`  // 单个检测器的默认超时（毫秒），0表示不限制
+	DetectorTimeouts map[string]int `mapstructure:"detector_timeouts"` // 检测器 -> 超时（毫秒）
+	DegradedPolicy   string         `mapstructure:"degraded_policy"`   // 有检测器超时或失败时的处理: review 至少转人工审核, pass 按其余检测器的结果判定
+	Cascade          CascadeConfig  `mapstructure:"cascade"`
+}
+
+// CascadeConfig 分级检测配置，先执行低成本检测器，分数落在不确定区间时才调用高成本检测器
+type CascadeConfig struct {
+	Enabled   bool     `mapstructure:"enabled"`
+	Detectors []string `mapstructure:"detectors"` // 高成本检测器，为空时为 llm, ensemble, nlp, semantic_nlp, ai
+	GrayZone  GrayZone `mapstructure:"gray_zone"` // 不确定区间
+}
+
+// GrayZone 不确定区间 [Min, Max)，低成本检测器的分数低于Min视为确定通过，不低于Max视为确定拒绝
+type GrayZone struct {
+	Min float32 `mapstructure:"min"`
+	Max float32 `mapstructure:"max"`
+}
+
+// NormalizerConfig 文本归一化配置
+type NormalizerConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Stages  []string `mapstructure:"stages"` // 归一化阶段及顺序，为空时使用默认阶段
 }
 
 // AIServiceConfig AI服务配置
@@ -78,8 +168,16 @@ type NLPServiceConfig struct {
 
 // RuleEngineConfig 规则引擎配置
 type RuleEngineConfig struct {
-	RuleUpdateInterval int    `mapstructure:"rule_update_interval"`
+	RuleUpdateInterval int    `mapstructure:"rule_update_interval"` // 规则定时重载间隔（秒），0表示不定时重载
 	DefaultRulesPath   string `mapstructure:"default_rules_path"`
+	Watch              bool   `mapstructure:"watch"`        // 是否监听规则文件变更
+	HistoryPath        string `mapstructure:"history_path"` // 规则集版本历史目录
+}
+
+// AdminConfig 管理接口配置
+type AdminConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Token   string `mapstructure:"token"` // 管理接口访问令牌，请求需携带 Authorization: Bearer <token>
 }
 
 // Load 加载配置文件
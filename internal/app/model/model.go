@@ -21,6 +21,20 @@ const (
 	ResultTypeWarning
 )
 
+// Severity 返回结果的严重程度，用于比较：通过 < 警告 < 人工审核 < 拒绝
+func (t ResultType) Severity() int {
+	switch t {
+	case ResultTypeWarning:
+		return 1
+	case ResultTypeReview:
+		return 2
+	case ResultTypeReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
 // RiskType 风险类型
 type RiskType int
 
@@ -43,15 +57,79 @@ const (
 	RiskTypeContextViolation
 	// RiskTypeSuspiciousBehavior 可疑行为
 	RiskTypeSuspiciousBehavior
+	// RiskTypeSelfHarm 自残自杀
+	RiskTypeSelfHarm
+	// RiskTypeEscalation 检测结果不确定（如多个模型判定不一致），需人工审核
+	RiskTypeEscalation
 )
 
+// riskTypeNames 风险类型名称
+var riskTypeNames = map[RiskType]string{
+	RiskTypeUnknown:            "unknown",
+	RiskTypeSensitiveWord:      "sensitive_word",
+	RiskTypeSpam:               "spam",
+	RiskTypeHarassment:         "harassment",
+	RiskTypeHateSpeech:         "hate_speech",
+	RiskTypeViolence:           "violence",
+	RiskTypeAdult:              "adult",
+	RiskTypeContextViolation:   "context_violation",
+	RiskTypeSuspiciousBehavior: "suspicious_behavior",
+	RiskTypeSelfHarm:           "self_harm",
+	RiskTypeEscalation:         "escalation",
+}
+
+// String 返回风险类型名称
+func (t RiskType) String() string {
+	if name, ok := riskTypeNames[t]; ok {
+		return name
+	}
+	return riskTypeNames[RiskTypeUnknown]
+}
+
+// ParseRiskType 根据名称解析风险类型
+func ParseRiskType(name string) (RiskType, bool) {
+	for t, n := range riskTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return RiskTypeUnknown, false
+}
+
 // CheckContext 检查上下文
 type CheckContext struct {
-	Content      string
+	Content      string // 原始内容
 	UserID       string
 	Scene        string
 	ContextItems []*ContextItem
 	ExtraData    map[string]string
+
+	// NormalizedContent 归一化后的内容，未启用归一化时为空
+	NormalizedContent string
+	// NormalizedOffsets 归一化内容第i个字符对应原始内容的rune下标
+	NormalizedOffsets []int
+}
+
+// DetectContent 返回用于检测的内容，优先使用归一化后的内容
+func (c *CheckContext) DetectContent() string {
+	if c.NormalizedContent != "" {
+		return c.NormalizedContent
+	}
+	return c.Content
+}
+
+// OriginalSpan 将检测内容中的rune区间[start, end)映射回原始内容
+func (c *CheckContext) OriginalSpan(start, end int) (int, int) {
+	if c.NormalizedContent == "" || len(c.NormalizedOffsets) == 0 || start >= end {
+		return start, end
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(c.NormalizedOffsets) {
+		end = len(c.NormalizedOffsets)
+	}
+	return c.NormalizedOffsets[start], c.NormalizedOffsets[end-1] + 1
 }
 
 // ContextItem 上下文内容项
@@ -129,3 +207,102 @@ func NewRiskItem(riskType RiskType, score float32, description string) *RiskItem
 		Details:     make(map[string]string),
 	}
 }
+
+// CheckTrace 审核过程追踪，解释模式下记录各检测器输出、规则求值、分数聚合及最终判定
+type CheckTrace struct {
+	Detectors        []*DetectorTrace   `json:"detectors"`
+	Rules            []*RuleTrace       `json:"rules"`
+	RuleVersion      string             `json:"rule_version"`
+	SceneProfile     string             `json:"scene_profile"`      // 使用的场景策略
+	CandidateRuleSet bool               `json:"candidate_rule_set"` // 是否使用请求中的候选规则集
+	Aggregation      []*AggregationStep `json:"aggregation"`
+	DecisionStage    string             `json:"decision_stage"` // 判定所在的检测阶段
+	Decision         *DecisionTrace     `json:"decision"`
+}
+
+// DetectorTrace 单个检测器的原始输出
+type DetectorTrace struct {
+	Name      string      `json:"name"`
+	Risks     []*RiskItem `json:"risks"`
+	LatencyMs float64     `json:"latency_ms"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// RuleTrace 单条规则的求值结果
+type RuleTrace struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Priority  int     `json:"priority"`
+	Action    string  `json:"action"`
+	Score     float32 `json:"score"`
+	Enabled   bool    `json:"enabled"`
+	Evaluated bool    `json:"evaluated"` // 禁用或被更高优先级的拦截规则提前终止时为false
+	Matched   bool    `json:"matched"`
+	Reason    string  `json:"reason"`
+}
+
+// AggregationStep 分数聚合的一步
+type AggregationStep struct {
+	Stage      string  `json:"stage"`  // detector: 检测器风险项, rule_merge: 规则提升已有风险项, rule_add: 规则新增风险项, aggregate: 聚合策略计算最终分数
+	Source     string  `json:"source"` // 检测器名称、规则ID或聚合策略名
+	RiskType   string  `json:"risk_type"`
+	Score      float32 `json:"score"`
+	Weight     float32 `json:"weight"`      // 场景对该检测器及风险类型的权重
+	MaxScore   float32 `json:"max_score"`   // 本步之后的最高分
+	TotalScore float32 `json:"total_score"` // 本步之后的累计分
+}
+
+// DecisionTrace 最终结果的判定依据
+type DecisionTrace struct {
+	Source    string     `json:"source"`            // rule: 阻止规则或比阈值判定更严重的规则动作, threshold: 按分数阈值判定
+	RuleID    string     `json:"rule_id,omitempty"` // 给出结果的规则
+	Threshold string     `json:"threshold,omitempty"`
+	Value     float32    `json:"value"` // 命中的阈值
+	Score     float32    `json:"score"`
+	Result    ResultType `json:"result"`
+}
+
+// AddDetector 记录检测器输出，trace为nil时忽略
+func (t *CheckTrace) AddDetector(name string, risks []*RiskItem, latency time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	dt := &DetectorTrace{
+		Name:      name,
+		Risks:     make([]*RiskItem, 0, len(risks)),
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	// 复制风险项，后续规则合并会修改原风险项的分数
+	for _, risk := range risks {
+		copied := *risk
+		dt.Risks = append(dt.Risks, &copied)
+	}
+	if err != nil {
+		dt.Error = err.Error()
+	}
+	t.Detectors = append(t.Detectors, dt)
+}
+
+// AddRule 记录规则求值结果，trace为nil时忽略
+func (t *CheckTrace) AddRule(rule *RuleTrace) {
+	if t == nil {
+		return
+	}
+	t.Rules = append(t.Rules, rule)
+}
+
+// AddStep 记录分数聚合步骤，trace为nil时忽略
+func (t *CheckTrace) AddStep(step *AggregationStep) {
+	if t == nil {
+		return
+	}
+	t.Aggregation = append(t.Aggregation, step)
+}
+
+// SetDecision 记录判定依据，trace为nil时忽略
+func (t *CheckTrace) SetDecision(decision *DecisionTrace) {
+	if t == nil {
+		return
+	}
+	t.Decision = decision
+}
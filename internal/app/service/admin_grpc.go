@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/aa12gq/content-risk-control/api/proto"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// GRPCAdminServer 管理gRPC服务实现（敏感词管理及解释模式审核）
+type GRPCAdminServer struct {
+	pb.UnimplementedSensitiveWordAdminServiceServer
+	service *ContentCheckService
+	logger  *zap.SugaredLogger
+}
+
+// authorize 校验metadata中的管理令牌
+func (s *GRPCAdminServer) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range md.Get("authorization") {
+		if validAdminToken(s.service.cfg.Admin.Token, authorization) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
+
+// ListWords 分页查询敏感词
+func (s *GRPCAdminServer) ListWords(ctx context.Context, req *pb.ListWordsRequest) (*pb.ListWordsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := wordPageSize(int(req.PageSize))
+
+	words, total := s.service.sensitiveWords.ListWords(req.Query, req.Category, page, pageSize)
+
+	response := &pb.ListWordsResponse{
+		Total:    int32(total),
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, word := range words {
+		response.Words = append(response.Words, &pb.SensitiveWord{
+			Word:      word.Word,
+			Category:  word.Category,
+			RiskType:  word.RiskType,
+			Score:     word.Score,
+			MatchMode: word.MatchMode,
+			Scenes:    word.Scenes,
+		})
+	}
+
+	return response, nil
+}
+
+// AddWords 添加或更新敏感词
+func (s *GRPCAdminServer) AddWords(ctx context.Context, req *pb.AddWordsRequest) (*pb.WordChangeResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.Words) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "words cannot be empty")
+	}
+
+	affected, err := s.service.sensitiveWords.UpsertWords(convertFromProtoWords(req.Words))
+	return s.wordChangeResponse(affected, err)
+}
+
+// RemoveWords 删除敏感词
+func (s *GRPCAdminServer) RemoveWords(ctx context.Context, req *pb.RemoveWordsRequest) (*pb.WordChangeResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.Words) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "words cannot be empty")
+	}
+
+	affected, err := s.service.sensitiveWords.RemoveWords(req.Words, req.Force)
+	return s.wordChangeResponse(affected, err)
+}
+
+// ImportWords 批量导入敏感词
+func (s *GRPCAdminServer) ImportWords(ctx context.Context, req *pb.ImportWordsRequest) (*pb.WordChangeResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	affected, err := s.service.sensitiveWords.ImportWords(convertFromProtoWords(req.Words), req.Replace, req.Force)
+	return s.wordChangeResponse(affected, err)
+}
+
+// wordChangeResponse 将词库变更结果转换为Proto响应
+func (s *GRPCAdminServer) wordChangeResponse(affected int, err error) (*pb.WordChangeResponse, error) {
+	if err != nil {
+		if errors.Is(err, ErrInvalidRequest) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Errorf("Failed to update sensitive words: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to update sensitive words: %v", err)
+	}
+
+	return &pb.WordChangeResponse{
+		Affected: int32(affected),
+		Total:    int32(len(s.service.sensitiveWords.GetAllWords())),
+	}, nil
+}
+
+// convertFromProtoWords 将Proto词条转换为词库词条
+func convertFromProtoWords(words []*pb.SensitiveWord) []*SensitiveWordEntry {
+	entries := make([]*SensitiveWordEntry, 0, len(words))
+	for _, word := range words {
+		entries = append(entries, &SensitiveWordEntry{
+			Word:      word.Word,
+			Category:  word.Category,
+			RiskType:  word.RiskType,
+			Score:     word.Score,
+			MatchMode: word.MatchMode,
+			Scenes:    word.Scenes,
+		})
+	}
+	return entries
+}
+
+// ExplainCheck 解释模式内容检查，求值过程包含规则条件的实际值及检测器原始输出，仅对管理员开放
+func (s *GRPCAdminServer) ExplainCheck(ctx context.Context, req *pb.ExplainCheckRequest) (*pb.ExplainCheckResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if req.Content == "" {
+		return nil, status.Error(codes.InvalidArgument, "content cannot be empty")
+	}
+
+	extraData := make(map[string]string)
+	if req.ExtraData != nil {
+		extraData = req.ExtraData
+	}
+
+	contextItems := make([]*model.ContextItem, 0, len(req.ContextItems))
+	for _, item := range req.ContextItems {
+		contextItems = append(contextItems, &model.ContextItem{
+			Content:   item.Content,
+			UserID:    item.UserId,
+			Timestamp: item.Timestamp,
+			ContentID: item.ContentId,
+		})
+	}
+
+	var candidateRules []byte
+	if req.CandidateRuleSet != "" {
+		candidateRules = []byte(req.CandidateRuleSet)
+	}
+
+	result, trace, err := s.service.ExplainCheck(ctx, req.Content, req.UserId, req.Scene, contextItems, extraData, candidateRules)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRequest) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Errorf("Failed to explain check: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to explain check: %v", err)
+	}
+
+	return &pb.ExplainCheckResponse{
+		Result: convertToProtoResponse(result),
+		Trace:  convertToProtoTrace(trace),
+	}, nil
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerAdminHandlers 注册管理接口，仅在配置开启时调用
+func registerAdminHandlers(api *gin.RouterGroup, httpServer *HTTPServer) {
+	admin := api.Group("/admin", AdminAuthMiddleware(httpServer.service.cfg.Admin.Token))
+	{
+		admin.GET("/words", httpServer.ListWords)
+		admin.POST("/words", httpServer.AddWords)
+		admin.DELETE("/words/:word", httpServer.RemoveWord)
+		admin.POST("/words/import", httpServer.ImportWords)
+		// 命中明细会暴露词库及其变体，仅对管理员开放
+		admin.POST("/words/explain", httpServer.ExplainSensitiveWords)
+		// 求值过程包含规则条件的实际值及检测器原始输出，仅对管理员开放
+		admin.POST("/check/explain", httpServer.ExplainCheck)
+
+		admin.GET("/rules", httpServer.ListRules)
+		admin.POST("/rules", httpServer.CreateRule)
+		admin.GET("/rules/versions", httpServer.ListRuleVersions)
+		admin.GET("/rules/versions/:version", httpServer.GetRuleVersion)
+		admin.POST("/rules/rollback", httpServer.RollbackRules)
+		admin.GET("/rules/:id", httpServer.GetRule)
+		admin.PUT("/rules/:id", httpServer.UpdateRule)
+		admin.DELETE("/rules/:id", httpServer.DeleteRule)
+		admin.POST("/rules/:id/enable", httpServer.EnableRule)
+		admin.POST("/rules/:id/disable", httpServer.DisableRule)
+
+		// 运行指标（如未知场景计数）包含词库及模型调用情况，仅对管理员开放
+		admin.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+	}
+}
+
+// adminIdentityKey 鉴权通过后管理员身份在请求上下文中的键
+const adminIdentityKey = "admin_identity"
+
+// AdminAuthMiddleware 管理接口鉴权中间件，未配置令牌时拒绝所有请求，
+// 鉴权通过后将管理员身份写入请求上下文，用于记录变更的作者
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	identity := adminIdentity(token)
+	return func(c *gin.Context) {
+		if !validAdminToken(token, c.GetHeader("Authorization")) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+
+		c.Set(adminIdentityKey, identity)
+		c.Next()
+	}
+}
+
+// adminIdentity 返回管理令牌对应的身份，包含令牌指纹以区分更换前后的令牌，不暴露令牌本身
+func adminIdentity(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "admin:" + hex.EncodeToString(sum[:4])
+}
+
+// ruleAuthor 返回规则变更的操作者：身份取自鉴权结果，请求中的author仅作为声明的作者一并记录
+func ruleAuthor(c *gin.Context, claimed string) RuleAuthor {
+	return RuleAuthor{
+		Identity: c.GetString(adminIdentityKey),
+		Claimed:  strings.TrimSpace(claimed),
+	}
+}
+
+// validAdminToken 校验 "Bearer <token>" 形式的授权信息，认证方案不区分大小写，缺少方案时拒绝
+func validAdminToken(token, authorization string) bool {
+	if token == "" {
+		return false
+	}
+	scheme, given, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	given = strings.TrimSpace(given)
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// HTTPWordsRequest 添加敏感词请求
+type HTTPWordsRequest struct {
+	Words []*SensitiveWordEntry `json:"words" binding:"required"`
+}
+
+// HTTPImportWordsRequest 批量导入敏感词请求
+type HTTPImportWordsRequest struct {
+	Words   []*SensitiveWordEntry `json:"words" binding:"required"`
+	Replace bool                  `json:"replace"`
+	Force   bool                  `json:"force"` // 跳过词条减少比例检查
+}
+
+// ListWords 分页查询敏感词
+func (s *HTTPServer) ListWords(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	pageSize = wordPageSize(pageSize)
+	if page < 1 {
+		page = 1
+	}
+
+	words, total := s.service.sensitiveWords.ListWords(c.Query("q"), c.Query("category"), page, pageSize)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"words":     words,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"version":   s.service.sensitiveWords.Version(),
+	})
+}
+
+// AddWords 添加或更新敏感词
+func (s *HTTPServer) AddWords(c *gin.Context) {
+	var req HTTPWordsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	affected, err := s.service.sensitiveWords.UpsertWords(req.Words)
+	s.respondWordChange(c, affected, err)
+}
+
+// RemoveWord 删除敏感词，查询参数 force=true 时跳过词条减少比例检查
+func (s *HTTPServer) RemoveWord(c *gin.Context) {
+	force, _ := strconv.ParseBool(c.Query("force"))
+	affected, err := s.service.sensitiveWords.RemoveWords([]string{c.Param("word")}, force)
+	if err == nil && affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Word not found",
+		})
+		return
+	}
+
+	s.respondWordChange(c, affected, err)
+}
+
+// ImportWords 批量导入敏感词
+func (s *HTTPServer) ImportWords(c *gin.Context) {
+	var req HTTPImportWordsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	affected, err := s.service.sensitiveWords.ImportWords(req.Words, req.Replace, req.Force)
+	s.respondWordChange(c, affected, err)
+}
+
+// respondWordChange 返回词库变更结果
+func (s *HTTPServer) respondWordChange(c *gin.Context, affected int, err error) {
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidRequest) {
+			code = http.StatusBadRequest
+		}
+		c.JSON(code, gin.H{
+			"success": false,
+			"error":   "Failed to update sensitive words: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"affected": affected,
+		"total":    len(s.service.sensitiveWords.GetAllWords()),
+		"version":  s.service.sensitiveWords.Version(),
+	})
+}
+
+// HTTPRuleChangeRequest 规则变更请求，每次变更都会生成一个新的规则集版本，
+// 版本作者为鉴权通过的管理员身份，author 作为声明的作者一并记录
+type HTTPRuleChangeRequest struct {
+	Rule    *Rule  `json:"rule"`
+	Author  string `json:"author"`
+	Comment string `json:"comment"`
+}
+
+// HTTPRollbackRequest 规则集回滚请求
+type HTTPRollbackRequest struct {
+	Version string `json:"version" binding:"required"`
+	Author  string `json:"author"`
+	Comment string `json:"comment"`
+}
+
+// ListRules 查询当前规则集的所有规则
+func (s *HTTPServer) ListRules(c *gin.Context) {
+	rules, err := s.service.ruleEngine.ListRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to list rules: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"rules":   rules,
+		"version": s.service.ruleEngine.Version(),
+	})
+}
+
+// GetRule 查询单条规则
+func (s *HTTPServer) GetRule(c *gin.Context) {
+	rule, err := s.service.ruleEngine.GetRule(c.Param("id"))
+	if err != nil {
+		s.respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"rule":    rule,
+		"version": s.service.ruleEngine.Version(),
+	})
+}
+
+// CreateRule 新增规则
+func (s *HTTPServer) CreateRule(c *gin.Context) {
+	req, ok := bindRuleChange(c)
+	if !ok {
+		return
+	}
+
+	version, err := s.service.ruleEngine.CreateRule(req.Rule, ruleAuthor(c, req.Author), req.Comment)
+	s.respondRuleChange(c, version, err)
+}
+
+// UpdateRule 更新规则
+func (s *HTTPServer) UpdateRule(c *gin.Context) {
+	req, ok := bindRuleChange(c)
+	if !ok {
+		return
+	}
+
+	version, err := s.service.ruleEngine.UpdateRule(c.Param("id"), req.Rule, ruleAuthor(c, req.Author), req.Comment)
+	s.respondRuleChange(c, version, err)
+}
+
+// EnableRule 启用规则
+func (s *HTTPServer) EnableRule(c *gin.Context) {
+	s.setRuleEnabled(c, true)
+}
+
+// DisableRule 禁用规则
+func (s *HTTPServer) DisableRule(c *gin.Context) {
+	s.setRuleEnabled(c, false)
+}
+
+// setRuleEnabled 启用或禁用规则
+func (s *HTTPServer) setRuleEnabled(c *gin.Context, enabled bool) {
+	req, ok := bindRuleChange(c)
+	if !ok {
+		return
+	}
+
+	version, err := s.service.ruleEngine.SetRuleEnabled(c.Param("id"), enabled, ruleAuthor(c, req.Author), req.Comment)
+	s.respondRuleChange(c, version, err)
+}
+
+// DeleteRule 删除规则
+func (s *HTTPServer) DeleteRule(c *gin.Context) {
+	req, ok := bindRuleChange(c)
+	if !ok {
+		return
+	}
+
+	version, err := s.service.ruleEngine.DeleteRule(c.Param("id"), ruleAuthor(c, req.Author), req.Comment)
+	s.respondRuleChange(c, version, err)
+}
+
+// ListRuleVersions 查询规则集版本历史
+func (s *HTTPServer) ListRuleVersions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"versions": s.service.ruleEngine.ListVersions(),
+		"current":  s.service.ruleEngine.Version(),
+	})
+}
+
+// GetRuleVersion 查询指定版本的规则集
+func (s *HTTPServer) GetRuleVersion(c *gin.Context) {
+	version, ok := s.service.ruleEngine.GetVersion(c.Param("version"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Version not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"version": version,
+	})
+}
+
+// RollbackRules 回滚规则集到指定版本
+func (s *HTTPServer) RollbackRules(c *gin.Context) {
+	var req HTTPRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	version, err := s.service.ruleEngine.Rollback(req.Version, ruleAuthor(c, req.Author), req.Comment)
+	s.respondRuleChange(c, version, err)
+}
+
+// bindRuleChange 解析规则变更请求，失败时直接返回400
+func bindRuleChange(c *gin.Context) (*HTTPRuleChangeRequest, bool) {
+	var req HTTPRuleChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// respondRuleChange 返回规则变更结果及生成的版本
+func (s *HTTPServer) respondRuleChange(c *gin.Context, version *RuleSetVersion, err error) {
+	if err != nil {
+		s.respondRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"version":        version.Version,
+		"author":         version.Author,
+		"claimed_author": version.ClaimedAuthor,
+		"comment":        version.Comment,
+		"created_at":     version.CreatedAt,
+	})
+}
+
+// respondRuleError 按错误类型返回对应的状态码
+func (s *HTTPServer) respondRuleError(c *gin.Context, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrRuleNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, ErrInvalidRequest):
+		code = http.StatusBadRequest
+	}
+	c.JSON(code, gin.H{
+		"success": false,
+		"error":   "Rule request failed: " + err.Error(),
+	})
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/aa12gq/content-risk-control/api/proto"
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+func TestValidAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		want          bool
+	}{
+		{"bearer token", "secret", "Bearer secret", true},
+		{"scheme is case insensitive", "secret", "bearer secret", true},
+		{"surrounding spaces", "secret", "  Bearer   secret ", true},
+		{"bare token", "secret", "secret", false},
+		{"wrong scheme", "secret", "Basic secret", false},
+		{"wrong token", "secret", "Bearer other", false},
+		{"empty authorization", "secret", "", false},
+		{"scheme only", "secret", "Bearer", false},
+		{"token not configured", "", "Bearer ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validAdminToken(tt.token, tt.authorization); got != tt.want {
+				t.Errorf("validAdminToken(%q, %q) = %v, want %v", tt.token, tt.authorization, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListWordsPageSize(t *testing.T) {
+	dict := &SensitiveWordDict{}
+	for i := 0; i < maxWordPageSize+10; i++ {
+		dict.Words = append(dict.Words, &SensitiveWordEntry{Word: fmt.Sprintf("word%04d", i)})
+	}
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{&staticWordSource{dict: dict}}, 0)
+
+	tests := []struct {
+		pageSize int
+		want     int
+	}{
+		{0, defaultWordPageSize},
+		{-1, defaultWordPageSize},
+		{10, 10},
+		{maxWordPageSize + 100, maxWordPageSize},
+	}
+	for _, tt := range tests {
+		words, total := sw.ListWords("", "", 1, tt.pageSize)
+		if len(words) != tt.want {
+			t.Errorf("ListWords(page_size=%d) returned %d words, want %d", tt.pageSize, len(words), tt.want)
+		}
+		if total != len(dict.Words) {
+			t.Errorf("ListWords(page_size=%d) total = %d, want %d", tt.pageSize, total, len(dict.Words))
+		}
+	}
+}
+
+func TestDebugVarsRequiresAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Admin.Enabled = true
+	cfg.Admin.Token = "secret"
+	engine := gin.New()
+	RegisterHTTPHandlers(engine, &ContentCheckService{cfg: cfg})
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		want          int
+	}{
+		{"public path removed", "/debug/vars", "", http.StatusNotFound},
+		{"missing token", "/api/v1/admin/debug/vars", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1/admin/debug/vars", "Bearer other", http.StatusUnauthorized},
+		{"admin token", "/api/v1/admin/debug/vars", "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("GET %s status = %d, want %d", tt.path, w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveWordForce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Admin.Enabled = true
+	cfg.Admin.Token = "secret"
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{&staticWordSource{dict: testDict("only")}}, 50)
+	engine := gin.New()
+	RegisterHTTPHandlers(engine, &ContentCheckService{cfg: cfg, sensitiveWords: sw})
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/v1/admin/words/only", http.StatusBadRequest},
+		{"/api/v1/admin/words/only?force=true", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("DELETE %s status = %d, want %d: %s", tt.path, w.Code, tt.want, w.Body.String())
+		}
+	}
+	if got := len(sw.GetAllWords()); got != 0 {
+		t.Errorf("dictionary has %d words, want 0", got)
+	}
+}
+
+// contextDetector 仅在带有上下文时返回风险项
+type contextDetector struct{}
+
+func (contextDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if len(checkCtx.ContextItems) == 0 {
+		return nil, nil
+	}
+	return []*model.RiskItem{model.NewRiskItem(model.RiskTypeHarassment, 90, "上下文中存在骚扰")}, nil
+}
+
+// newExplainTestService 创建开启管理接口、使用 contextDetector 的审核服务
+func newExplainTestService(t *testing.T) *ContentCheckService {
+	t.Helper()
+	s := newTestCheckService(t, map[string]detector.Detector{"context": contextDetector{}})
+	s.cfg.Admin.Enabled = true
+	s.cfg.Admin.Token = "secret"
+	return s
+}
+
+func TestExplainCheckRequiresAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	RegisterHTTPHandlers(engine, newExplainTestService(t))
+
+	tests := []struct {
+		name          string
+		path          string
+		body          string
+		authorization string
+		want          int
+		wantTrace     bool
+		wantResult    model.ResultType
+	}{
+		{
+			name: "public check ignores explain",
+			path: "/api/v1/check",
+			body: `{"content":"你好","explain":true}`,
+			want: http.StatusOK, wantResult: model.ResultTypePass,
+		},
+		{
+			name: "missing token",
+			path: "/api/v1/admin/check/explain",
+			body: `{"content":"你好"}`,
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:          "admin explain",
+			path:          "/api/v1/admin/check/explain",
+			body:          `{"content":"你好"}`,
+			authorization: "Bearer secret",
+			want:          http.StatusOK, wantTrace: true, wantResult: model.ResultTypePass,
+		},
+		{
+			name:          "admin explain with context",
+			path:          "/api/v1/admin/check/explain",
+			body:          `{"content":"你好","context_items":[{"content":"之前的消息","user_id":"u2"}]}`,
+			authorization: "Bearer secret",
+			want:          http.StatusOK, wantTrace: true, wantResult: model.ResultTypeReject,
+		},
+		{
+			name:          "invalid candidate rule set",
+			path:          "/api/v1/admin/check/explain",
+			body:          `{"content":"你好","rule_set":{"rules":[{"id":"r1"}]}}`,
+			authorization: "Bearer secret",
+			want:          http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("POST %s status = %d, want %d: %s", tt.path, w.Code, tt.want, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := resp["trace"]; ok != tt.wantTrace {
+				t.Errorf("response has trace = %v, want %v", ok, tt.wantTrace)
+			}
+			var result model.ResultType
+			if err := json.Unmarshal(resp["result"], &result); err != nil {
+				t.Fatal(err)
+			}
+			if result != tt.wantResult {
+				t.Errorf("result = %v, want %v", result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestGRPCExplainCheckRequiresAdminToken(t *testing.T) {
+	s := &GRPCAdminServer{service: newExplainTestService(t), logger: zap.NewNop().Sugar()}
+	req := &pb.ExplainCheckRequest{
+		Content:      "你好",
+		ContextItems: []*pb.ContextItem{{Content: "之前的消息", UserId: "u2"}},
+	}
+
+	if _, err := s.ExplainCheck(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("ExplainCheck without token error = %v, want Unauthenticated", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+	resp, err := s.ExplainCheck(ctx, req)
+	if err != nil {
+		t.Fatalf("ExplainCheck: %v", err)
+	}
+	if resp.Trace == nil || len(resp.Trace.Detectors) != 1 {
+		t.Fatalf("trace = %+v, want one detector", resp.Trace)
+	}
+	if resp.Result.Result != pb.ResultType_REJECT {
+		t.Errorf("result = %v, want REJECT", resp.Result.Result)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"expvar"
+	"fmt"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+)
+
+// 判定所在的检测阶段
+const (
+	DecisionStageFast      = "fast"      // 低成本检测器的分数在不确定区间外，未调用高成本检测器
+	DecisionStageEscalated = "escalated" // 分数落在不确定区间，结合高成本检测器的结果判定
+	DecisionStageFull      = "full"      // 未启用分级检测，所有检测器同时执行
+)
+
+// defaultCascadeDetectors 未配置时作为高成本阶段的检测器
+var defaultCascadeDetectors = []string{"llm", "ensemble", "nlp", "semantic_nlp", "ai"}
+
+// cascadeDecisions 各检测阶段的判定次数，通过 /debug/vars 暴露，用于调整不确定区间
+var cascadeDecisions = expvar.NewMap("cascade_decisions")
+
+// detectorCascade 分级检测
+type detectorCascade struct {
+	enabled   bool
+	escalated map[string]bool
+	grayZone  config.GrayZone
+}
+
+// newDetectorCascade 解析分级检测配置
+func newDetectorCascade(cfg config.CascadeConfig) (*detectorCascade, error) {
+	cascade := &detectorCascade{enabled: cfg.Enabled, grayZone: cfg.GrayZone}
+	if !cfg.Enabled {
+		return cascade, nil
+	}
+
+	if z := cfg.GrayZone; z.Min < 0 || z.Max > maxRiskScore || z.Min >= z.Max {
+		return nil, fmt.Errorf("cascade gray zone must satisfy 0 <= min < max <= 100")
+	}
+
+	detectors := cfg.Detectors
+	if len(detectors) == 0 {
+		detectors = defaultCascadeDetectors
+	}
+	cascade.escalated = make(map[string]bool, len(detectors))
+	for _, name := range detectors {
+		cascade.escalated[name] = true
+	}
+
+	return cascade, nil
+}
+
+// isFast 判断检测器是否在低成本阶段执行，未启用分级检测时所有检测器都在首个阶段执行
+func (c *detectorCascade) isFast(name string) bool {
+	return !c.escalated[name]
+}
+
+// isEscalated 判断检测器是否在高成本阶段执行
+func (c *detectorCascade) isEscalated(name string) bool {
+	return c.escalated[name]
+}
+
+// inGrayZone 判断低成本阶段的分数是否需要升级到高成本阶段
+func (c *detectorCascade) inGrayZone(score float32) bool {
+	return score >= c.grayZone.Min && score < c.grayZone.Max
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+// countingDetector 记录调用次数并返回固定风险项
+type countingDetector struct {
+	calls *atomic.Int32
+	risks []*model.RiskItem
+}
+
+func (d countingDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	d.calls.Add(1)
+	return d.risks, nil
+}
+
+func TestNewDetectorCascade(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.CascadeConfig
+		wantErr bool
+	}{
+		{"disabled ignores gray zone", config.CascadeConfig{GrayZone: config.GrayZone{Min: 80, Max: 20}}, false},
+		{"valid", config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: 20, Max: 80}}, false},
+		{"empty zone", config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: 50, Max: 50}}, true},
+		{"negative min", config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: -1, Max: 50}}, true},
+		{"max above 100", config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: 20, Max: 120}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newDetectorCascade(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("newDetectorCascade error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	cascade, err := newDetectorCascade(config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: 20, Max: 80}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cascade.isEscalated("llm") || cascade.isFast("llm") || !cascade.isFast("keyword") {
+		t.Error("default cascade detectors should escalate llm and keep keyword in the fast stage")
+	}
+}
+
+func TestCascadeShortCircuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		fastScore float32
+		wantStage string
+		wantCalls int32
+	}{
+		{"clean content stays fast", 0, DecisionStageFast, 0},
+		{"below gray zone stays fast", 10, DecisionStageFast, 0},
+		{"gray zone escalates", 50, DecisionStageEscalated, 1},
+		{"gray zone lower bound escalates", 20, DecisionStageEscalated, 1},
+		{"upper bound stays fast", 80, DecisionStageFast, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fast staticDetector
+			if tt.fastScore > 0 {
+				fast = staticDetector{model.NewRiskItem(model.RiskTypeSpam, tt.fastScore, "垃圾信息")}
+			}
+			calls := &atomic.Int32{}
+			s := newTestCheckService(t, map[string]detector.Detector{
+				"keyword": fast,
+				"llm":     countingDetector{calls: calls, risks: []*model.RiskItem{model.NewRiskItem(model.RiskTypeHarassment, 90, "骚扰")}},
+			})
+			cascade, err := newDetectorCascade(config.CascadeConfig{Enabled: true, GrayZone: config.GrayZone{Min: 20, Max: 80}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.cascade = cascade
+
+			result, trace, err := s.ExplainCheck(context.Background(), "测试内容", "u1", "", nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if trace.DecisionStage != tt.wantStage {
+				t.Errorf("decision stage = %q, want %q", trace.DecisionStage, tt.wantStage)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("llm called %d times, want %d", got, tt.wantCalls)
+			}
+			if tt.wantStage == DecisionStageEscalated && result.Result != model.ResultTypeReject {
+				t.Errorf("escalated result = %v, want reject from the llm risk", result.Result)
+			}
+		})
+	}
+}
+
+func TestCascadeDisabledRunsAllDetectors(t *testing.T) {
+	calls := &atomic.Int32{}
+	s := newTestCheckService(t, map[string]detector.Detector{
+		"keyword": staticDetector{},
+		"llm":     countingDetector{calls: calls},
+	})
+
+	_, trace, err := s.ExplainCheck(context.Background(), "测试内容", "u1", "", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.DecisionStage != DecisionStageFull || calls.Load() != 1 {
+		t.Errorf("stage = %q, llm calls = %d, want full stage with one call", trace.DecisionStage, calls.Load())
+	}
+}
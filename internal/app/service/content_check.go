@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/aa12gq/content-risk-control/internal/app/config"
 	"github.com/aa12gq/content-risk-control/internal/app/model"
 	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
 )
 
 var (
@@ -36,12 +38,18 @@ type ContentCheckService struct {
 	ruleEngine     *RuleEngine
 	redisClient    *redis.Client
 	sensitiveWords *SensitiveWords
+	normalizer     *normalizer.Normalizer
 	detectors      map[string]detector.Detector
+	detectorScenes map[string]map[string]bool // 检测器实例 -> 生效场景，nil表示所有场景生效
+	scenes         *sceneProfiles
+	cascade        *detectorCascade
+	modelServer    *ModelServer // 同进程运行的NLP模型服务，状态在健康检查中报告
 	mu             sync.RWMutex
 }
 
-// NewContentCheckService 创建内容审核服务
-func NewContentCheckService(cfg *config.Config, logger *zap.SugaredLogger) (*ContentCheckService, error) {
+// NewContentCheckService 创建内容审核服务，modelServer 为同进程运行的NLP模型服务，未运行时为nil，
+// 模型加载失败时不作为进程内的分析服务，仅在健康检查中报告
+func NewContentCheckService(cfg *config.Config, logger *zap.SugaredLogger, modelServer *ModelServer) (*ContentCheckService, error) {
 	// 创建Redis客户端
 	var redisClient *redis.Client
 	var err error
@@ -61,71 +69,62 @@ func NewContentCheckService(cfg *config.Config, logger *zap.SugaredLogger) (*Con
 	}
 
 	// 加载规则引擎
-	ruleEngine, err := NewRuleEngine(cfg.RuleEngine.DefaultRulesPath, logger)
+	ruleEngine, err := NewRuleEngine(cfg.RuleEngine.DefaultRulesPath, cfg.RuleEngine.HistoryPath, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize rule engine: %w", err)
 	}
 
-	// 初始化敏感词检测器
-	sensitiveWords := NewSensitiveWords(logger)
-
-	// 初始化各种内容检测器
-	detectors := make(map[string]detector.Detector)
-	detectors["sensitive"] = detector.NewSensitiveWordDetector(sensitiveWords)
-	detectors["spam"] = detector.NewSpamDetector()
-	detectors["harassment"] = detector.NewHarassmentDetector()
-
-	// 初始化语义检测器
-	semanticDetector := detector.NewSemanticDetector(
-		cfg.ContentCheck.ContextHistorySize,
-		0.3, // 默认阈值
-	)
-	detectors["semantic"] = semanticDetector
-
-	// 如果启用了NLP服务，初始化NLP检测器
-	if cfg.NLPService.Enabled {
-		nlpEndpoint := fmt.Sprintf("http://localhost:%d", cfg.NLPService.ServerPort)
-		nlpDetector, err := detector.NewNLPDetector(
-			nlpEndpoint,
-			cfg.NLPService.Threshold,
-			cfg.NLPService.ContextSize,
-		)
+	// 初始化文本归一化流水线
+	var textNormalizer *normalizer.Normalizer
+	if cfg.ContentCheck.Normalizer.Enabled {
+		textNormalizer, err = normalizer.New(cfg.ContentCheck.Normalizer.Stages)
 		if err != nil {
-			logger.Warnf("Failed to initialize NLP detector: %v", err)
-		} else {
-			logger.Info("NLP detector initialized successfully")
-			detectors["nlp"] = nlpDetector
+			return nil, fmt.Errorf("failed to initialize normalizer: %w", err)
 		}
+		logger.Infof("Text normalizer enabled with stages: %v", textNormalizer.Stages())
 	}
 
-	// 如果配置了使用机器学习模型，则初始化AI检测器
-	if cfg.ContentCheck.UseMLModel {
-		aiDetector, err := detector.NewAIDetector(cfg.AIService.URL, cfg.AIService.APIKey, time.Duration(cfg.AIService.Timeout)*time.Millisecond)
-		if err != nil {
-			logger.Warnf("Failed to initialize AI detector: %v", err)
-		} else {
-			detectors["ai"] = aiDetector
-		}
+	// 初始化敏感词检测器
+	wordSources, err := NewWordSources(cfg.ContentCheck.WordSources.Sources, redisClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize sensitive word sources: %w", err)
+	}
+	sensitiveWords := NewSensitiveWordsWithSources(logger, wordSources, cfg.ContentCheck.WordSources.MaxShrinkPercent)
+	if textNormalizer != nil {
+		sensitiveWords.SetNormalizer(textNormalizer)
+	}
+	if cfg.ContentCheck.PinyinMatch {
+		sensitiveWords.SetPinyinMatching(true)
 	}
 
-	// 如果配置了使用本地大语言模型，则初始化语义NLP检测器
-	if cfg.NLPService.UseLocalLLM {
-		localLLMAPI := cfg.NLPService.LocalLLMAPI
-		if localLLMAPI == "" {
-			// 默认使用Ollama API地址
-			localLLMAPI = "http://localhost:11434/api/chat"
-		}
+	if !validDegradedPolicy(cfg.ContentCheck.Detection.DegradedPolicy) {
+		return nil, fmt.Errorf("unknown degraded policy %q", cfg.ContentCheck.Detection.DegradedPolicy)
+	}
+	cascade, err := newDetectorCascade(cfg.ContentCheck.Detection.Cascade)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize detector cascade: %w", err)
+	}
 
-		semanticNLPDetector, err := detector.NewSemanticNLPDetector(
-			localLLMAPI,
-			cfg.NLPService.Threshold,
-			cfg.NLPService.ContextSize,
-		)
-		if err != nil {
-			logger.Warnf("Failed to initialize local semantic NLP detector: %v", err)
-		} else {
-			logger.Info("Local semantic NLP detector initialized successfully")
-			detectors["semantic_nlp"] = semanticNLPDetector
+	// 按配置的检测器实例初始化各种内容检测器
+	deps := detector.Dependencies{SensitiveWords: sensitiveWords}
+	if modelServer != nil && modelServer.Status().State != ModelServerFailed {
+		deps.ModelAnalyzer = modelServer
+	}
+	detectors, detectorScenes, err := newDetectors(cfg, detector.DefaultRegistry(), deps, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize detectors: %w", err)
+	}
+
+	// 加载场景策略
+	scenes, err := newSceneProfiles(cfg.ContentCheck.Scenes, float32(cfg.ContentCheck.RiskScoreThreshold))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scene profiles: %w", err)
+	}
+	for _, profile := range scenes.profiles {
+		for name := range profile.Detectors {
+			if _, ok := detectors[name]; !ok {
+				logger.Warnf("Scene %s enables detector %s which is not available", profile.Name, name)
+			}
 		}
 	}
 
@@ -135,11 +134,20 @@ func NewContentCheckService(cfg *config.Config, logger *zap.SugaredLogger) (*Con
 		ruleEngine:     ruleEngine,
 		redisClient:    redisClient,
 		sensitiveWords: sensitiveWords,
+		normalizer:     textNormalizer,
 		detectors:      detectors,
+		detectorScenes: detectorScenes,
+		scenes:         scenes,
+		cascade:        cascade,
+		modelServer:    modelServer,
 	}
 
 	// 启动敏感词定时更新
 	go service.scheduleSensitiveWordUpdate(time.Duration(cfg.ContentCheck.SensitiveWordsUpdateInterval) * time.Second)
+	go sensitiveWords.Watch(context.Background())
+
+	// 启动规则重载
+	go ruleEngine.Start(context.Background(), time.Duration(cfg.RuleEngine.RuleUpdateInterval)*time.Second, cfg.RuleEngine.Watch)
 
 	return service, nil
 }
@@ -154,7 +162,7 @@ func (s *ContentCheckService) CheckContent(ctx context.Context, content string,
 	requestID := fmt.Sprintf("req_%d_%s", time.Now().UnixNano(), userID)
 
 	// 尝试从缓存获取结果
-	cacheKey := fmt.Sprintf("content_check:%s", model.HashString(content))
+	cacheKey := s.resultCacheKey(content, userID, scene, extraData)
 	cachedResult, err := s.getCachedResult(ctx, cacheKey)
 	if err == nil {
 		s.logger.Debugf("Cache hit for content check: %s", cacheKey)
@@ -166,7 +174,7 @@ func (s *ContentCheckService) CheckContent(ctx context.Context, content string,
 	startTime := time.Now()
 
 	// 执行内容检查
-	result, err := s.doContentCheck(content, userID, scene, nil, extraData)
+	result, err := s.doContentCheck(ctx, content, userID, scene, nil, extraData, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -175,8 +183,8 @@ func (s *ContentCheckService) CheckContent(ctx context.Context, content string,
 	result.RequestID = requestID
 	result.CostTime = time.Since(startTime).Milliseconds()
 
-	// 缓存结果
-	if result.Result != model.ResultTypeReject {
+	// 缓存结果，有检测器降级的结果不缓存
+	if result.Result != model.ResultTypeReject && result.Extra["degraded"] == "" {
 		s.cacheResult(ctx, cacheKey, result, time.Duration(s.cfg.ContentCheck.CacheTTL)*time.Second)
 	}
 
@@ -195,7 +203,7 @@ func (s *ContentCheckService) CheckContentWithContext(ctx context.Context, conte
 	startTime := time.Now()
 
 	// 执行上下文内容检查
-	result, err := s.doContentCheck(content, userID, scene, contextItems, extraData)
+	result, err := s.doContentCheck(ctx, content, userID, scene, contextItems, extraData, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -296,8 +304,40 @@ func (s *ContentCheckService) StreamCheckContent(stream model.ContentCheckStream
 	}
 }
 
-// doContentCheck 执行内容检查的核心逻辑
-func (s *ContentCheckService) doContentCheck(content, userID, scene string, contextItems []*model.ContextItem, extraData map[string]string) (*model.CheckResult, error) {
+// ExplainCheck 以解释模式执行审核并返回完整的求值过程，不读写缓存；
+// candidateRules不为空时使用请求中的候选规则集评估，不影响当前规则集
+func (s *ContentCheckService) ExplainCheck(ctx context.Context, content string, userID, scene string, contextItems []*model.ContextItem, extraData map[string]string, candidateRules []byte) (*model.CheckResult, *model.CheckTrace, error) {
+	if content == "" {
+		return nil, nil, ErrEmptyContent
+	}
+
+	var ruleSet *RuleSet
+	if len(candidateRules) > 0 {
+		var err error
+		ruleSet, err = ParseCandidateRuleSet(candidateRules)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	requestID := fmt.Sprintf("req_explain_%d_%s", time.Now().UnixNano(), userID)
+	startTime := time.Now()
+
+	trace := &model.CheckTrace{CandidateRuleSet: ruleSet != nil}
+	result, err := s.doContentCheck(ctx, content, userID, scene, contextItems, extraData, ruleSet, trace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result.RequestID = requestID
+	result.CostTime = time.Since(startTime).Milliseconds()
+
+	return result, trace, nil
+}
+
+// doContentCheck 执行内容检查的核心逻辑，ruleSet为nil时使用当前规则集，
+// trace不为nil时记录求值过程
+func (s *ContentCheckService) doContentCheck(ctx context.Context, content, userID, scene string, contextItems []*model.ContextItem, extraData map[string]string, ruleSet *RuleSet, trace *model.CheckTrace) (*model.CheckResult, error) {
 	// 初始化检查上下文
 	checkCtx := &model.CheckContext{
 		Content:      content,
@@ -307,44 +347,133 @@ func (s *ContentCheckService) doContentCheck(content, userID, scene string, cont
 		ExtraData:    extraData,
 	}
 
+	// 场景策略决定启用的检测器、分数权重、规则覆盖及判定阈值
+	profile := s.scenes.resolve(scene)
+	if trace != nil {
+		trace.SceneProfile = profile.Name
+	}
+
+	// 0. 文本归一化，检测器基于归一化内容匹配，命中位置可映射回原始内容
+	if s.normalizer != nil {
+		normalized := s.normalizer.Normalize(content)
+		checkCtx.NormalizedContent = normalized.Text
+		checkCtx.NormalizedOffsets = normalized.Offsets
+	}
+
 	// 应用规则引擎
 	var allRisks []*model.RiskItem
+	var scoreInputs []ScoreInput
+	inputIndex := make(map[*model.RiskItem]int) // 风险项在 scoreInputs 中的位置，规则合并时更新对应输入
 	var totalScore float32
 	var maxScore float32
 
-	// 1. 先应用各种检测器
-	for name, detector := range s.detectors {
-		risks, err := detector.Detect(checkCtx)
-		if err != nil {
-			s.logger.Warnf("Detector %s failed: %v", name, err)
-			continue
-		}
+	// 1. 并发执行各检测器，检测总预算内未完成或失败的检测器记为降级
+	if timeout := s.cfg.ContentCheck.Detection.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
 
-		for _, risk := range risks {
-			allRisks = append(allRisks, risk)
-			totalScore += risk.Score
-			if risk.Score > maxScore {
-				maxScore = risk.Score
+	var degraded []string
+	collect := func(outcomes []*detectorOutcome) {
+		for _, outcome := range outcomes {
+			name := outcome.name
+			trace.AddDetector(name, outcome.risks, outcome.latency, outcome.err)
+			if outcome.err != nil {
+				s.logger.Warnf("Detector %s failed: %v", name, outcome.err)
+				degraded = append(degraded, name)
+				continue
 			}
+
+			for _, risk := range outcome.risks {
+				allRisks = append(allRisks, risk)
+				// 需人工审核的风险项只表示结论不确定，由 applyEscalation 将结果调整为人工审核，不参与分数聚合
+				if risk.Type == model.RiskTypeEscalation {
+					continue
+				}
+				weight := profile.Weight(name, risk.Type)
+				input := ScoreInput{Source: name, RiskType: risk.Type, Score: risk.Score, Weight: weight}
+				inputIndex[risk] = len(scoreInputs)
+				scoreInputs = append(scoreInputs, input)
+				score := input.weighted()
+				totalScore += score
+				if score > maxScore {
+					maxScore = score
+				}
+				trace.AddStep(&model.AggregationStep{
+					Stage:      "detector",
+					Source:     name,
+					RiskType:   risk.Type.String(),
+					Score:      risk.Score,
+					Weight:     weight,
+					MaxScore:   maxScore,
+					TotalScore: totalScore,
+				})
+			}
+		}
+	}
+
+	// 启用分级检测时先执行低成本检测器，聚合分数落在不确定区间才执行高成本检测器
+	stage := DecisionStageFull
+	collect(s.runDetectors(ctx, checkCtx, s.profileDetectors(profile, checkCtx.Scene, s.cascade.isFast)))
+	if s.cascade.enabled {
+		stage = DecisionStageFast
+		fastScore := profile.Aggregator.Aggregate(scoreInputs)
+		trace.AddStep(&model.AggregationStep{
+			Stage:      "cascade",
+			Source:     profile.Aggregator.Name(),
+			Score:      fastScore,
+			Weight:     1,
+			MaxScore:   maxScore,
+			TotalScore: totalScore,
+		})
+		if escalated := s.profileDetectors(profile, checkCtx.Scene, s.cascade.isEscalated); len(escalated) > 0 && s.cascade.inGrayZone(fastScore) {
+			stage = DecisionStageEscalated
+			collect(s.runDetectors(ctx, checkCtx, escalated))
 		}
 	}
+	cascadeDecisions.Add(stage, 1)
+	if trace != nil {
+		trace.DecisionStage = stage
+	}
 
 	// 2. 应用规则引擎
-	engineResult, err := s.ruleEngine.Evaluate(checkCtx, allRisks)
+	var ruleVersion string
+	engineResult, err := s.ruleEngine.EvaluateWith(checkCtx, allRisks, RuleEvalOptions{
+		RuleSet:   ruleSet,
+		Overrides: profile.RuleOverrides,
+		Trace:     trace,
+	})
 	if err != nil {
 		s.logger.Errorf("Rule engine evaluation failed: %v", err)
 		// 即使规则引擎失败，我们仍然可以基于检测器的结果给出判断
 	} else {
+		ruleVersion = engineResult.RuleVersion
+		if trace != nil {
+			trace.RuleVersion = ruleVersion
+		}
+
 		// 合并规则引擎的结果
 		for _, risk := range engineResult.Risks {
+			stage := "rule_add"
+			input := ScoreInput{
+				Source:   risk.Details["rule_id"],
+				RiskType: risk.Type,
+				Score:    risk.Score,
+				Weight:   1,
+			}
 			found := false
 			for _, existing := range allRisks {
 				if existing.Type == risk.Type {
 					found = true
-					// 更新已有风险项的分数和描述
+					stage = "rule_merge"
+					// 更新已有风险项的分数和描述，聚合时以规则分数替换该风险项原有的输入，避免同一风险计入两次
 					if risk.Score > existing.Score {
 						existing.Score = risk.Score
 						existing.Description = risk.Description
+						if i, ok := inputIndex[existing]; ok {
+							scoreInputs[i] = input
+						}
 					}
 					break
 				}
@@ -352,48 +481,91 @@ func (s *ContentCheckService) doContentCheck(content, userID, scene string, cont
 
 			if !found {
 				allRisks = append(allRisks, risk)
+				inputIndex[risk] = len(scoreInputs)
+				scoreInputs = append(scoreInputs, input)
 			}
 
 			if risk.Score > maxScore {
 				maxScore = risk.Score
 			}
+			trace.AddStep(&model.AggregationStep{
+				Stage:      stage,
+				Source:     risk.Details["rule_id"],
+				RiskType:   risk.Type.String(),
+				Score:      risk.Score,
+				Weight:     1,
+				MaxScore:   maxScore,
+				TotalScore: totalScore,
+			})
 		}
 
-		// 如果规则引擎明确给出了结果，则使用它的判断
+		// 阻止规则命中时直接拒绝，其余规则的动作在阈值判定后取较严重者
 		if engineResult.HasExplicitResult {
-			return &model.CheckResult{
+			trace.SetDecision(&model.DecisionTrace{
+				Source: "rule",
+				RuleID: engineResult.RuleID,
+				Score:  engineResult.Score,
+				Result: engineResult.Result,
+			})
+			return s.applyDegradedPolicy(s.applyEscalation(&model.CheckResult{
 				Result:     engineResult.Result,
 				RiskScore:  engineResult.Score,
 				Risks:      allRisks,
 				Suggestion: engineResult.Suggestion,
-			}, nil
+				Extra: map[string]string{
+					"rule_version":   ruleVersion,
+					"scene_profile":  profile.Name,
+					"decision_stage": stage,
+				},
+			}, trace), degraded, trace), nil
 		}
 	}
 
-	// 3. 基于风险分数计算最终结果
-	finalScore := maxScore
-	var result model.ResultType
+	// 3. 按场景的聚合策略计算最终分数，再按场景阈值判定结果
+	finalScore := profile.Aggregator.Aggregate(scoreInputs)
+	trace.AddStep(&model.AggregationStep{
+		Stage:      "aggregate",
+		Source:     profile.Aggregator.Name(),
+		Score:      finalScore,
+		Weight:     1,
+		MaxScore:   maxScore,
+		TotalScore: totalScore,
+	})
+	result, threshold, thresholdValue := profile.Decide(finalScore)
+	trace.SetDecision(&model.DecisionTrace{
+		Source:    "threshold",
+		Threshold: threshold,
+		Value:     thresholdValue,
+		Score:     finalScore,
+		Result:    result,
+	})
 
-	// 根据配置的阈值判断结果
-	if finalScore >= float32(s.cfg.ContentCheck.RiskScoreThreshold) {
-		result = model.ResultTypeReject
-	} else if finalScore >= float32(s.cfg.ContentCheck.RiskScoreThreshold)*0.7 {
-		result = model.ResultTypeReview
-	} else if finalScore >= float32(s.cfg.ContentCheck.RiskScoreThreshold)*0.5 {
-		result = model.ResultTypeWarning
-	} else {
-		result = model.ResultTypePass
+	// 命中规则的动作比阈值判定更严重时以规则为准，规则不会降低阈值判定的结果
+	if engineResult != nil && engineResult.Result.Severity() > result.Severity() {
+		result = engineResult.Result
+		trace.SetDecision(&model.DecisionTrace{
+			Source: "rule",
+			RuleID: engineResult.RuleID,
+			Score:  engineResult.Score,
+			Result: result,
+		})
 	}
 
 	// 生成最终结果
 	suggestion := s.generateSuggestion(result, allRisks)
-	return &model.CheckResult{
+	return s.applyDegradedPolicy(s.applyEscalation(&model.CheckResult{
 		Result:     result,
 		RiskScore:  finalScore,
 		Risks:      allRisks,
 		Suggestion: suggestion,
-		Extra:      map[string]string{"total_score": fmt.Sprintf("%.2f", totalScore)},
-	}, nil
+		Extra: map[string]string{
+			"total_score":    fmt.Sprintf("%.2f", totalScore),
+			"aggregator":     profile.Aggregator.Name(),
+			"rule_version":   ruleVersion,
+			"scene_profile":  profile.Name,
+			"decision_stage": stage,
+		},
+	}, trace), degraded, trace), nil
 }
 
 // generateSuggestion 生成建议
@@ -413,6 +585,28 @@ func (s *ContentCheckService) generateSuggestion(result model.ResultType, risks
 	}
 }
 
+// resultCacheKey 生成审核结果缓存键。结果取决于场景策略、内容及规则集版本，规则重载或回滚后不再命中旧结果；
+// 规则条件引用了用户ID或扩展字段时，对应的值也计入缓存键，避免将一个用户的结果返回给另一个用户
+func (s *ContentCheckService) resultCacheKey(content, userID, scene string, extraData map[string]string) string {
+	version, usesUserID, extraKeys := s.ruleEngine.CacheScope()
+
+	var sb strings.Builder
+	sb.WriteString(content)
+	if usesUserID {
+		fmt.Fprintf(&sb, "\x00user_id=%q", userID)
+	}
+	for _, key := range extraKeys {
+		// 区分字段缺失与空值，exists 条件的结果不同
+		if value, ok := extraData[key]; ok {
+			fmt.Fprintf(&sb, "\x00extra.%s=%q", key, value)
+		} else {
+			fmt.Fprintf(&sb, "\x00extra.%s", key)
+		}
+	}
+
+	return fmt.Sprintf("content_check:%s:%s:%s", scene, version, model.HashString(sb.String()))
+}
+
 // getCachedResult 从缓存获取审核结果
 func (s *ContentCheckService) getCachedResult(ctx context.Context, key string) (*model.CheckResult, error) {
 	if s.redisClient == nil {
@@ -450,6 +644,22 @@ func (s *ContentCheckService) cacheResult(ctx context.Context, key string, resul
 	}
 }
 
+// ExplainSensitiveWords 返回内容的全部敏感词命中，包括被豁免短语抑制的命中，用于排查误报与漏报
+func (s *ContentCheckService) ExplainSensitiveWords(content, userID, scene string) []detector.WordMatch {
+	checkCtx := &model.CheckContext{
+		Content: content,
+		UserID:  userID,
+		Scene:   scene,
+	}
+	if s.normalizer != nil {
+		normalized := s.normalizer.Normalize(content)
+		checkCtx.NormalizedContent = normalized.Text
+		checkCtx.NormalizedOffsets = normalized.Offsets
+	}
+
+	return s.sensitiveWords.ExplainWords(checkCtx)
+}
+
 // scheduleSensitiveWordUpdate 定时更新敏感词库
 func (s *ContentCheckService) scheduleSensitiveWordUpdate(interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -460,7 +670,7 @@ func (s *ContentCheckService) scheduleSensitiveWordUpdate(interval time.Duration
 		if err != nil {
 			s.logger.Errorf("Failed to update sensitive words: %v", err)
 		} else {
-			s.logger.Infof("Sensitive words updated successfully")
+			s.logger.Infof("Sensitive words updated successfully, version %s", s.sensitiveWords.Version().ID)
 		}
 	}
 }
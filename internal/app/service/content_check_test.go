@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+// staticDetector 返回固定风险项的检测器
+type staticDetector []*model.RiskItem
+
+func (d staticDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	return d, nil
+}
+
+// newTestCheckService 创建使用空规则集、默认场景策略及给定检测器的审核服务
+func newTestCheckService(t *testing.T, detectors map[string]detector.Detector) *ContentCheckService {
+	t.Helper()
+	return newTestCheckServiceWithRules(t, `{"rules":[]}`, detectors)
+}
+
+// newTestCheckServiceWithRules 创建使用给定规则集、默认场景策略及给定检测器的审核服务
+func newTestCheckServiceWithRules(t *testing.T, rules string, detectors map[string]detector.Detector) *ContentCheckService {
+	t.Helper()
+	logger := zap.NewNop().Sugar()
+
+	rulesPath := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ruleEngine, err := NewRuleEngine(rulesPath, "", logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenes, err := newSceneProfiles(nil, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ContentCheckService{
+		cfg:        &config.Config{},
+		logger:     logger,
+		ruleEngine: ruleEngine,
+		detectors:  detectors,
+		scenes:     scenes,
+		cascade:    &detectorCascade{},
+	}
+}
+
+func TestEscalationRiskExcludedFromScore(t *testing.T) {
+	escalation := model.NewRiskItem(model.RiskTypeEscalation, 90, "多个模型的判定不一致，需人工审核")
+	s := newTestCheckService(t, map[string]detector.Detector{
+		"ensemble": staticDetector{escalation},
+	})
+
+	result, _, err := s.ExplainCheck(context.Background(), "测试内容", "u1", "", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 分歧本身不计分，结果由 applyEscalation 调整为人工审核而不是拒绝
+	if result.Result != model.ResultTypeReview {
+		t.Errorf("result = %v, want review", result.Result)
+	}
+	if result.RiskScore != 0 {
+		t.Errorf("risk score = %v, want 0", result.RiskScore)
+	}
+	if len(result.Risks) != 1 || result.Risks[0].Type != model.RiskTypeEscalation {
+		t.Errorf("risks = %+v, want the escalation risk", result.Risks)
+	}
+}
+
+// testDecisionRules 阻止、审核、标记规则各一条
+const testDecisionRules = `{"rules":[
+	{"id":"block_violence","name":"暴力","enabled":true,"priority":100,"action":"block","score":95,"risk_type":"violence",
+	 "condition":{"field":"content","op":"contains","value":"砍人"}},
+	{"id":"review_link","name":"链接","enabled":true,"priority":90,"action":"review","score":40,"risk_type":"spam",
+	 "condition":{"field":"content","op":"contains","value":"http"}},
+	{"id":"mark_phone","name":"手机号","enabled":true,"priority":80,"action":"mark","score":30,"risk_type":"spam",
+	 "condition":{"field":"content","op":"regex","value":"\\d{11}"}}
+]}`
+
+func TestRuleActionCombinedWithThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		risks      []*model.RiskItem
+		want       model.ResultType
+		wantSource string
+	}{
+		{
+			name:       "mark rule does not downgrade a detector reject",
+			content:    "联系13800138000",
+			risks:      []*model.RiskItem{model.NewRiskItem(model.RiskTypeHarassment, 90, "骚扰")},
+			want:       model.ResultTypeReject,
+			wantSource: "threshold",
+		},
+		{
+			name:       "review rule raises a low score",
+			content:    "看看 http://example.com",
+			risks:      []*model.RiskItem{model.NewRiskItem(model.RiskTypeHarassment, 10, "骚扰")},
+			want:       model.ResultTypeReview,
+			wantSource: "rule",
+		},
+		{
+			name:       "most severe rule action wins over higher score",
+			content:    "http://example.com 13800138000",
+			want:       model.ResultTypeReview,
+			wantSource: "rule",
+		},
+		{
+			name:       "mark rule alone warns",
+			content:    "联系13800138000",
+			want:       model.ResultTypeWarning,
+			wantSource: "rule",
+		},
+		{
+			name:       "block rule short circuits",
+			content:    "我要砍人",
+			want:       model.ResultTypeReject,
+			wantSource: "rule",
+		},
+		{
+			name:       "no rule matched",
+			content:    "你好",
+			want:       model.ResultTypePass,
+			wantSource: "threshold",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestCheckServiceWithRules(t, testDecisionRules, map[string]detector.Detector{
+				"static": staticDetector(tt.risks),
+			})
+			result, trace, err := s.ExplainCheck(context.Background(), tt.content, "u1", "", nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Result != tt.want {
+				t.Errorf("result = %v, want %v", result.Result, tt.want)
+			}
+			if trace.Decision == nil || trace.Decision.Source != tt.wantSource {
+				t.Errorf("decision = %+v, want source %s", trace.Decision, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestResultCacheKey(t *testing.T) {
+	const rules = `{"rules":[
+		{"id":"vip","name":"会员","enabled":false,"action":"none","condition":{"all":[
+			{"field":"user_id","op":"eq","value":"u1"},
+			{"not":{"field":"extra.level","op":"exists"}}
+		]}}
+	]}`
+	s := newTestCheckServiceWithRules(t, rules, nil)
+	base := s.resultCacheKey("内容", "u1", "chat", map[string]string{"level": "1", "ip": "1.1.1.1"})
+
+	tests := []struct {
+		name     string
+		content  string
+		userID   string
+		scene    string
+		extra    map[string]string
+		wantSame bool
+	}{
+		{"unreferenced extra is ignored", "内容", "u1", "chat", map[string]string{"level": "1", "ip": "2.2.2.2"}, true},
+		{"content", "内容2", "u1", "chat", map[string]string{"level": "1"}, false},
+		{"scene", "内容", "u1", "comment", map[string]string{"level": "1"}, false},
+		{"referenced user id", "内容", "u2", "chat", map[string]string{"level": "1"}, false},
+		{"referenced extra value", "内容", "u1", "chat", map[string]string{"level": "2"}, false},
+		{"referenced extra missing", "内容", "u1", "chat", nil, false},
+		{"referenced extra empty", "内容", "u1", "chat", map[string]string{"level": ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := s.resultCacheKey(tt.content, tt.userID, tt.scene, tt.extra)
+			if got := key == base; got != tt.wantSame {
+				t.Errorf("key %s same as base %s: %v, want %v", key, base, got, tt.wantSame)
+			}
+		})
+	}
+
+	// 规则集版本变化后不再命中旧结果
+	if _, err := s.ruleEngine.SetRuleEnabled("vip", true, RuleAuthor{Identity: "tester"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if key := s.resultCacheKey("内容", "u1", "chat", map[string]string{"level": "1"}); key == base {
+		t.Errorf("key %s unchanged after the rule set changed", key)
+	}
+
+	// 规则不引用用户字段时不同用户共用缓存
+	plain := newTestCheckService(t, nil)
+	if plain.resultCacheKey("内容", "u1", "", nil) != plain.resultCacheKey("内容", "u2", "", map[string]string{"a": "b"}) {
+		t.Error("key depends on user fields the rules do not reference")
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+// 检测器降级时的处理策略
+const (
+	DegradedPolicyReview = "review" // 结果至少为人工审核
+	DegradedPolicyPass   = "pass"   // 按其余检测器的结果判定
+)
+
+// detectorOutcome 单个检测器的执行结果
+type detectorOutcome struct {
+	name    string
+	risks   []*model.RiskItem
+	err     error
+	latency time.Duration
+}
+
+// runDetectors 并发执行检测器，每个检测器受独立超时及ctx的总预算约束，
+// 超时的检测器不再等待，结果顺序与names一致
+func (s *ContentCheckService) runDetectors(ctx context.Context, checkCtx *model.CheckContext, names []string) []*detectorOutcome {
+	outcomes := make([]*detectorOutcome, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			outcomes[i] = s.runDetector(ctx, name, checkCtx)
+		}(i, name)
+	}
+	wg.Wait()
+
+	return outcomes
+}
+
+// profileDetectors 返回场景策略启用、生效场景包含scene且满足filter的检测器，按名称排序以保证聚合顺序稳定，
+// 检测器的生效场景按请求中的原始场景匹配，而非场景策略名
+func (s *ContentCheckService) profileDetectors(profile *SceneProfile, scene string, filter func(name string) bool) []string {
+	names := make([]string, 0, len(s.detectors))
+	for name := range s.detectors {
+		if scenes := s.detectorScenes[name]; scenes != nil && !scenes[scene] {
+			continue
+		}
+		if profile.RunsDetector(name) && filter(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runDetector 在超时内执行单个检测器，检测器未响应ctx时也会在超时后返回
+func (s *ContentCheckService) runDetector(ctx context.Context, name string, checkCtx *model.CheckContext) *detectorOutcome {
+	if timeout := s.detectorTimeout(name); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan *detectorOutcome, 1)
+	go func() {
+		risks, err := s.detectors[name].Detect(ctx, checkCtx)
+		done <- &detectorOutcome{name: name, risks: risks, err: err}
+	}()
+
+	var outcome *detectorOutcome
+	select {
+	case outcome = <-done:
+	case <-ctx.Done():
+		outcome = &detectorOutcome{name: name}
+	}
+	// 超时或调用方取消后的结果不再采用
+	if ctx.Err() != nil {
+		outcome.risks = nil
+		outcome.err = fmt.Errorf("detector %s aborted: %w", name, ctx.Err())
+	}
+	outcome.latency = time.Since(start)
+
+	return outcome
+}
+
+// detectorTimeout 返回检测器的超时时间，0表示不限制
+func (s *ContentCheckService) detectorTimeout(name string) time.Duration {
+	detection := s.cfg.ContentCheck.Detection
+	if timeout, ok := detection.DetectorTimeouts[name]; ok {
+		return time.Duration(timeout) * time.Millisecond
+	}
+	return time.Duration(detection.DetectorTimeout) * time.Millisecond
+}
+
+// CircuitBreakers 返回带熔断器的检测器的熔断状态
+func (s *ContentCheckService) CircuitBreakers() map[string]detector.BreakerStatus {
+	breakers := make(map[string]detector.BreakerStatus)
+	for name, d := range s.detectors {
+		if cb, ok := d.(detector.CircuitBreaking); ok {
+			breakers[name] = cb.CircuitBreaker().Status()
+		}
+		if mcb, ok := d.(detector.MultiCircuitBreaking); ok {
+			for member, cb := range mcb.CircuitBreakers() {
+				breakers[name+"."+member] = cb.Status()
+			}
+		}
+	}
+	return breakers
+}
+
+// applyDegradedPolicy 有检测器超时或失败时记录到结果中，并按策略调整结果
+func (s *ContentCheckService) applyDegradedPolicy(result *model.CheckResult, degraded []string, trace *model.CheckTrace) *model.CheckResult {
+	if len(degraded) == 0 {
+		return result
+	}
+
+	policy := s.cfg.ContentCheck.Detection.DegradedPolicy
+	if policy == "" {
+		policy = DegradedPolicyReview
+	}
+	result.Extra["degraded"] = strings.Join(degraded, ",")
+	result.Extra["degraded_policy"] = policy
+
+	if policy == DegradedPolicyReview && (result.Result == model.ResultTypePass || result.Result == model.ResultTypeWarning) {
+		result.Result = model.ResultTypeReview
+		result.Suggestion = s.generateSuggestion(result.Result, result.Risks)
+		trace.SetDecision(&model.DecisionTrace{
+			Source: "degraded",
+			Score:  result.RiskScore,
+			Result: result.Result,
+		})
+	}
+
+	return result
+}
+
+// applyEscalation 存在需人工审核的风险项（如多个模型判定不一致）时，将通过或警告的结果调整为人工审核
+func (s *ContentCheckService) applyEscalation(result *model.CheckResult, trace *model.CheckTrace) *model.CheckResult {
+	if result.Result != model.ResultTypePass && result.Result != model.ResultTypeWarning {
+		return result
+	}
+	for _, risk := range result.Risks {
+		if risk.Type != model.RiskTypeEscalation {
+			continue
+		}
+		result.Result = model.ResultTypeReview
+		result.Suggestion = s.generateSuggestion(result.Result, result.Risks)
+		trace.SetDecision(&model.DecisionTrace{
+			Source: "escalation",
+			Score:  result.RiskScore,
+			Result: result.Result,
+		})
+		break
+	}
+	return result
+}
+
+// validDegradedPolicy 校验降级处理策略
+func validDegradedPolicy(policy string) bool {
+	switch policy {
+	case "", DegradedPolicyReview, DegradedPolicyPass:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+func TestProfileDetectorsMatchesRawScene(t *testing.T) {
+	s := &ContentCheckService{
+		detectors: map[string]detector.Detector{
+			"keyword":  nil,
+			"live_llm": nil,
+			"chat_llm": nil,
+		},
+		detectorScenes: map[string]map[string]bool{
+			"live_llm": {"live": true},
+			"chat_llm": {"chat": true},
+		},
+	}
+	all := func(string) bool { return true }
+
+	// live 未配置场景策略，使用 default 策略，但 live_llm 仍按原始场景生效
+	fallback := &SceneProfile{Name: defaultSceneProfile}
+	if got, want := s.profileDetectors(fallback, "live", all), []string{"keyword", "live_llm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("live scene detectors = %v, want %v", got, want)
+	}
+	if got, want := s.profileDetectors(fallback, "", all), []string{"keyword"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("empty scene detectors = %v, want %v", got, want)
+	}
+
+	chat := &SceneProfile{Name: "chat", Detectors: map[string]bool{"chat_llm": true}}
+	if got, want := s.profileDetectors(chat, "chat", all), []string{"chat_llm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chat scene detectors = %v, want %v", got, want)
+	}
+}
+
+// slowDetector 忽略ctx，延迟后返回固定风险项
+type slowDetector struct {
+	delay time.Duration
+	risks []*model.RiskItem
+}
+
+func (d slowDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	time.Sleep(d.delay)
+	return d.risks, nil
+}
+
+// errDetector 总是返回错误的检测器
+type errDetector struct{}
+
+func (errDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	return nil, errors.New("detector unavailable")
+}
+
+func TestRunDetectorsTimeout(t *testing.T) {
+	risk := model.NewRiskItem(model.RiskTypeSpam, 80, "垃圾信息")
+	s := newTestCheckService(t, map[string]detector.Detector{
+		"fast":     staticDetector{risk},
+		"slow":     slowDetector{delay: 500 * time.Millisecond, risks: []*model.RiskItem{risk}},
+		"patient":  slowDetector{delay: 100 * time.Millisecond, risks: []*model.RiskItem{risk}},
+		"no_limit": slowDetector{delay: 100 * time.Millisecond, risks: []*model.RiskItem{risk}},
+	})
+	s.cfg.ContentCheck.Detection.DetectorTimeout = 50
+	s.cfg.ContentCheck.Detection.DetectorTimeouts = map[string]int{"patient": 300, "no_limit": 0}
+
+	names := []string{"slow", "fast", "patient", "no_limit"}
+	start := time.Now()
+	outcomes := s.runDetectors(context.Background(), &model.CheckContext{Content: "测试"}, names)
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("runDetectors took %v, want it to stop waiting for the slow detector", elapsed)
+	}
+
+	tests := []struct {
+		name     string
+		wantErr  bool
+		wantRisk bool
+	}{
+		{"slow", true, false},
+		{"fast", false, true},
+		{"patient", false, true},
+		{"no_limit", false, true},
+	}
+	for i, tt := range tests {
+		outcome := outcomes[i]
+		if outcome.name != tt.name {
+			t.Fatalf("outcome %d = %s, want %s", i, outcome.name, tt.name)
+		}
+		if (outcome.err != nil) != tt.wantErr {
+			t.Errorf("%s error = %v, want error %v", tt.name, outcome.err, tt.wantErr)
+		}
+		if tt.wantErr && !errors.Is(outcome.err, context.DeadlineExceeded) {
+			t.Errorf("%s error = %v, want deadline exceeded", tt.name, outcome.err)
+		}
+		if (len(outcome.risks) > 0) != tt.wantRisk {
+			t.Errorf("%s risks = %+v, want risks %v", tt.name, outcome.risks, tt.wantRisk)
+		}
+	}
+}
+
+func TestRunDetectorsCanceled(t *testing.T) {
+	s := newTestCheckService(t, map[string]detector.Detector{
+		"slow": slowDetector{delay: 500 * time.Millisecond},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	outcomes := s.runDetectors(ctx, &model.CheckContext{Content: "测试"}, []string{"slow"})
+	if !errors.Is(outcomes[0].err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want the request deadline", outcomes[0].err)
+	}
+}
+
+func TestDegradedPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   model.ResultType
+	}{
+		{"", model.ResultTypeReview},
+		{DegradedPolicyReview, model.ResultTypeReview},
+		{DegradedPolicyPass, model.ResultTypePass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			s := newTestCheckService(t, map[string]detector.Detector{
+				"ok":     staticDetector{},
+				"broken": errDetector{},
+			})
+			s.cfg.ContentCheck.Detection.DegradedPolicy = tt.policy
+
+			result, _, err := s.ExplainCheck(context.Background(), "你好", "u1", "", nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Result != tt.want {
+				t.Errorf("result = %v, want %v", result.Result, tt.want)
+			}
+			if result.Extra["degraded"] != "broken" {
+				t.Errorf("extra degraded = %q, want broken", result.Extra["degraded"])
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+)
+
+// newDetectors 按配置的检测器实例创建检测器，返回实例名到检测器及生效场景的映射，
+// 生效场景为nil表示所有场景生效；配置项无效时返回错误，其余创建失败（如依赖的服务不可用）时记录警告并跳过该实例
+func newDetectors(cfg *config.Config, registry *detector.Registry, deps detector.Dependencies, logger *zap.SugaredLogger) (map[string]detector.Detector, map[string]map[string]bool, error) {
+	instances := cfg.ContentCheck.Detectors
+	if len(instances) == 0 {
+		instances = builtinDetectorInstances(cfg)
+	}
+
+	detectors := make(map[string]detector.Detector, len(instances))
+	scenes := make(map[string]map[string]bool)
+	seen := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		name := instance.Name
+		if name == "" {
+			name = instance.Type
+		}
+		if !registry.Has(instance.Type) {
+			return nil, nil, fmt.Errorf("detector %s: unknown type %q", name, instance.Type)
+		}
+		if seen[name] {
+			return nil, nil, fmt.Errorf("duplicate detector name %q", name)
+		}
+		seen[name] = true
+
+		if instance.Enabled != nil && !*instance.Enabled {
+			logger.Infof("Detector %s is disabled", name)
+			continue
+		}
+
+		instanceDeps := deps
+		instanceDeps.Instance = name
+		d, err := registry.Create(instance.Type, instance.Options, instanceDeps)
+		if errors.Is(err, detector.ErrInvalidOptions) {
+			return nil, nil, fmt.Errorf("detector %s: %w", name, err)
+		}
+		if err != nil {
+			logger.Warnf("Failed to initialize detector %s (%s): %v", name, instance.Type, err)
+			continue
+		}
+		detectors[name] = d
+
+		if len(instance.Scenes) > 0 {
+			scenes[name] = make(map[string]bool, len(instance.Scenes))
+			for _, scene := range instance.Scenes {
+				scenes[name][scene] = true
+			}
+		}
+		logger.Infof("Detector %s (%s) initialized", name, instance.Type)
+	}
+
+	return detectors, scenes, nil
+}
+
+// builtinDetectorInstances 未配置检测器实例时，按 use_ml_model、nlp_service 等配置生成内置检测器实例
+func builtinDetectorInstances(cfg *config.Config) []config.DetectorInstanceConfig {
+	instances := []config.DetectorInstanceConfig{
+		{Type: "sensitive"},
+		{Type: "spam"},
+		{Type: "harassment"},
+		{Type: "semantic", Options: map[string]interface{}{
+			"context_size": cfg.ContentCheck.ContextHistorySize,
+			"threshold":    0.3, // 默认阈值
+		}},
+	}
+
+	// 如果启用了NLP服务，初始化调用同进程模型服务的检测器
+	if cfg.NLPService.Enabled {
+		instances = append(instances, config.DetectorInstanceConfig{Type: "model_server", Options: map[string]interface{}{
+			"threshold":    cfg.NLPService.Threshold,
+			"context_size": cfg.NLPService.ContextSize,
+		}})
+	}
+
+	// 如果配置了使用机器学习模型，则初始化AI检测器
+	if cfg.ContentCheck.UseMLModel {
+		instances = append(instances, config.DetectorInstanceConfig{Type: "ai", Options: map[string]interface{}{
+			"url":     cfg.AIService.URL,
+			"api_key": cfg.AIService.APIKey,
+			"timeout": cfg.AIService.Timeout, // 毫秒
+		}})
+	}
+
+	// 如果配置了使用本地大语言模型，则初始化语义NLP检测器，未配置地址时使用Ollama默认地址
+	if cfg.NLPService.UseLocalLLM {
+		provider := detector.LLMProviderOllama
+		if cfg.NLPService.LocalLLMType != "" && cfg.NLPService.LocalLLMType != detector.LLMProviderOllama {
+			// llamacpp、vllm 等本地服务均提供OpenAI兼容接口
+			provider = detector.LLMProviderOpenAI
+		}
+		instances = append(instances, config.DetectorInstanceConfig{Type: "semantic_nlp", Options: map[string]interface{}{
+			"provider":     provider,
+			"endpoint":     cfg.NLPService.LocalLLMAPI,
+			"model":        cfg.NLPService.ModelName,
+			"threshold":    cfg.NLPService.Threshold,
+			"context_size": cfg.NLPService.ContextSize,
+		}})
+	}
+
+	// 内容会发送给大语言模型时，检测试图操纵审核模型的提示词注入
+	if cfg.NLPService.UseLocalLLM {
+		instances = append(instances, config.DetectorInstanceConfig{Type: "injection"})
+	}
+
+	return instances
+}
@@ -26,6 +26,14 @@ func RegisterGRPCServer(server *grpc.Server, service *ContentCheckService) {
 		logger:  service.logger,
 	}
 	pb.RegisterContentCheckServiceServer(server, grpcServer)
+
+	// 管理服务
+	if service.cfg.Admin.Enabled {
+		pb.RegisterSensitiveWordAdminServiceServer(server, &GRPCAdminServer{
+			service: service,
+			logger:  service.logger,
+		})
+	}
 }
 
 // CheckContent 检查单条内容
@@ -178,20 +186,85 @@ func convertToProtoResponse(result *model.CheckResult) *pb.CheckContentResponse
 	}
 
 	if result.Risks != nil {
-		for _, risk := range result.Risks {
-			protoRisk := &pb.RiskItem{
-				Type:        pb.RiskType(risk.Type),
-				Score:       risk.Score,
-				Description: risk.Description,
-			}
-
-			if risk.Details != nil {
-				protoRisk.Details = risk.Details
-			}
-
-			response.Risks = append(response.Risks, protoRisk)
-		}
+		response.Risks = convertToProtoRisks(result.Risks)
 	}
 
 	return response
 }
+
+// convertToProtoRisks 将风险项转换为Proto风险项
+func convertToProtoRisks(risks []*model.RiskItem) []*pb.RiskItem {
+	protoRisks := make([]*pb.RiskItem, 0, len(risks))
+	for _, risk := range risks {
+		protoRisk := &pb.RiskItem{
+			Type:        pb.RiskType(risk.Type),
+			Score:       risk.Score,
+			Description: risk.Description,
+		}
+
+		if risk.Details != nil {
+			protoRisk.Details = risk.Details
+		}
+
+		protoRisks = append(protoRisks, protoRisk)
+	}
+	return protoRisks
+}
+
+// convertToProtoTrace 将求值过程转换为Proto消息
+func convertToProtoTrace(trace *model.CheckTrace) *pb.CheckTrace {
+	protoTrace := &pb.CheckTrace{
+		RuleVersion:      trace.RuleVersion,
+		SceneProfile:     trace.SceneProfile,
+		CandidateRuleSet: trace.CandidateRuleSet,
+		DecisionStage:    trace.DecisionStage,
+	}
+
+	for _, d := range trace.Detectors {
+		protoTrace.Detectors = append(protoTrace.Detectors, &pb.DetectorTrace{
+			Name:      d.Name,
+			Risks:     convertToProtoRisks(d.Risks),
+			LatencyMs: d.LatencyMs,
+			Error:     d.Error,
+		})
+	}
+
+	for _, r := range trace.Rules {
+		protoTrace.Rules = append(protoTrace.Rules, &pb.RuleTrace{
+			Id:        r.ID,
+			Name:      r.Name,
+			Priority:  int32(r.Priority),
+			Action:    r.Action,
+			Score:     r.Score,
+			Enabled:   r.Enabled,
+			Evaluated: r.Evaluated,
+			Matched:   r.Matched,
+			Reason:    r.Reason,
+		})
+	}
+
+	for _, step := range trace.Aggregation {
+		protoTrace.Aggregation = append(protoTrace.Aggregation, &pb.AggregationStep{
+			Stage:      step.Stage,
+			Source:     step.Source,
+			RiskType:   step.RiskType,
+			Score:      step.Score,
+			Weight:     step.Weight,
+			MaxScore:   step.MaxScore,
+			TotalScore: step.TotalScore,
+		})
+	}
+
+	if d := trace.Decision; d != nil {
+		protoTrace.Decision = &pb.DecisionTrace{
+			Source:    d.Source,
+			RuleId:    d.RuleID,
+			Threshold: d.Threshold,
+			Value:     d.Value,
+			Score:     d.Score,
+			Result:    pb.ResultType(d.Result),
+		}
+	}
+
+	return protoTrace
+}
@@ -1,12 +1,15 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
 )
 
 // HTTPServer HTTP服务
@@ -29,6 +32,11 @@ func RegisterHTTPHandlers(engine *gin.Engine, service *ContentCheckService) {
 		api.GET("/health", httpServer.HealthCheck)
 	}
 
+	// 管理接口
+	if service.cfg.Admin.Enabled {
+		registerAdminHandlers(api, httpServer)
+	}
+
 	engine.Use(gin.Recovery())
 	engine.Use(CORSMiddleware())
 	engine.Use(RequestLoggerMiddleware())
@@ -180,8 +188,31 @@ func (s *HTTPServer) CheckContentWithContext(c *gin.Context) {
 		return
 	}
 
-	contextItems := make([]*model.ContextItem, 0, len(req.ContextItems))
-	for _, item := range req.ContextItems {
+	result, err := s.service.CheckContentWithContext(c.Request.Context(), req.Content, req.UserID, req.Scene, convertContextItems(req.ContextItems), req.ExtraData)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to check content: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"result":     result.Result,
+		"risk_score": result.RiskScore,
+		"risks":      result.Risks,
+		"request_id": result.RequestID,
+		"suggestion": result.Suggestion,
+		"cost_time":  result.CostTime,
+		"extra":      result.Extra,
+	})
+}
+
+// convertContextItems 转换请求中的上下文项
+func convertContextItems(items []*HTTPContextItem) []*model.ContextItem {
+	contextItems := make([]*model.ContextItem, 0, len(items))
+	for _, item := range items {
 		contextItems = append(contextItems, &model.ContextItem{
 			Content:   item.Content,
 			UserID:    item.UserID,
@@ -189,10 +220,40 @@ func (s *HTTPServer) CheckContentWithContext(c *gin.Context) {
 			ContentID: item.ContentID,
 		})
 	}
+	return contextItems
+}
+
+// HTTPExplainCheckRequest 解释模式检查请求，context_items 为空时等同于 /check，否则等同于 /check_with_context
+type HTTPExplainCheckRequest struct {
+	HTTPCheckWithContextRequest
+	// RuleSet 使用的候选规则集，格式与规则文件相同，为空时使用当前规则集
+	RuleSet json.RawMessage `json:"rule_set"`
+}
+
+// ExplainCheck 以解释模式检查内容，返回结果及求值过程。求值过程包含规则条件的实际值、检测器原始输出
+// 及候选规则集的评估结果，注册在需鉴权的管理接口下
+func (s *HTTPServer) ExplainCheck(c *gin.Context) {
+	var req HTTPExplainCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
 
-	result, err := s.service.CheckContentWithContext(c.Request.Context(), req.Content, req.UserID, req.Scene, contextItems, req.ExtraData)
+	candidateRules := []byte(req.RuleSet)
+	if string(candidateRules) == "null" {
+		candidateRules = nil
+	}
+
+	result, trace, err := s.service.ExplainCheck(c.Request.Context(), req.Content, req.UserID, req.Scene, convertContextItems(req.ContextItems), req.ExtraData, candidateRules)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidRequest) {
+			code = http.StatusBadRequest
+		}
+		c.JSON(code, gin.H{
 			"success": false,
 			"error":   "Failed to check content: " + err.Error(),
 		})
@@ -208,14 +269,88 @@ func (s *HTTPServer) CheckContentWithContext(c *gin.Context) {
 		"suggestion": result.Suggestion,
 		"cost_time":  result.CostTime,
 		"extra":      result.Extra,
+		"trace":      trace,
 	})
 }
 
-// HealthCheck 健康检查
-func (s *HTTPServer) HealthCheck(c *gin.Context) {
+// HTTPWordHit 敏感词命中明细
+type HTTPWordHit struct {
+	Word         string  `json:"word"`
+	Category     string  `json:"category"`
+	RiskType     string  `json:"risk_type"`
+	Score        float32 `json:"score"`
+	MatchType    string  `json:"match_type"`
+	Start        int     `json:"start"`
+	End          int     `json:"end"`
+	SuppressedBy string  `json:"suppressed_by,omitempty"`
+}
+
+// ExplainSensitiveWords 返回内容的敏感词命中明细，被豁免短语抑制的命中单独列出，注册在需鉴权的管理接口下
+func (s *HTTPServer) ExplainSensitiveWords(c *gin.Context) {
+	var req HTTPCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	hits := make([]*HTTPWordHit, 0)
+	suppressed := make([]*HTTPWordHit, 0)
+	for _, m := range s.service.ExplainSensitiveWords(req.Content, req.UserID, req.Scene) {
+		hit := &HTTPWordHit{
+			Word:         m.Word,
+			Category:     m.Category,
+			RiskType:     m.RiskType.String(),
+			Score:        m.Score,
+			MatchType:    m.MatchType,
+			Start:        m.Start,
+			End:          m.End,
+			SuppressedBy: m.SuppressedBy,
+		}
+		if m.SuppressedBy != "" {
+			suppressed = append(suppressed, hit)
+		} else {
+			hits = append(hits, hit)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"service": "content-risk-control",
-		"time":    time.Now().Format(time.RFC3339),
+		"success":    true,
+		"hits":       hits,
+		"suppressed": suppressed,
 	})
 }
+
+// HealthCheck 健康检查，有检测器熔断或规则文件监听出错时状态为degraded
+func (s *HTTPServer) HealthCheck(c *gin.Context) {
+	status := "ok"
+	breakers := s.service.CircuitBreakers()
+	for _, breaker := range breakers {
+		if breaker.State != detector.BreakerClosed.String() {
+			status = "degraded"
+		}
+	}
+	ruleWatch := s.service.ruleEngine.WatchStatus()
+	if ruleWatch.State == RuleWatchRetrying {
+		status = "degraded"
+	}
+
+	response := gin.H{
+		"service":          "content-risk-control",
+		"time":             time.Now().Format(time.RFC3339),
+		"circuit_breakers": breakers,
+		"rule_watch":       ruleWatch,
+	}
+	if s.service.modelServer != nil {
+		modelServer := s.service.modelServer.Status()
+		if modelServer.State != ModelServerReady || modelServer.ServeError != "" {
+			status = "degraded"
+		}
+		response["model_server"] = modelServer
+	}
+	response["status"] = status
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/pkg/classifier"
+)
+
+// trainTestModel 使用样例数据训练模型并保存到临时目录，返回模型路径
+func trainTestModel(t *testing.T) string {
+	t.Helper()
+	f, err := os.Open("../../../test/nlp_train_sample.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	examples, err := classifier.ReadExamples(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _, err := classifier.Train(examples, classifier.TrainOptions{Version: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelPath := filepath.Join(t.TempDir(), "nlp_model")
+	if err := m.Save(modelPath); err != nil {
+		t.Fatal(err)
+	}
+	return modelPath
+}
+
+func TestModelServerWaitReady(t *testing.T) {
+	s := NewModelServer(zap.NewNop().Sugar(), "", trainTestModel(t), 0)
+	req := classifier.AnalyzeRequest{Text: "你这个废物", AnalysisTypes: []string{classifier.TaskToxicity}}
+
+	// 启动前不就绪，分析返回 errModelNotLoaded
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.WaitReady(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitReady before start = %v, want deadline exceeded", err)
+	}
+	if _, err := s.Analyze(req); !errors.Is(err, errModelNotLoaded) {
+		t.Fatalf("Analyze before start = %v, want errModelNotLoaded", err)
+	}
+	if state := s.Status().State; state != ModelServerLoading {
+		t.Errorf("state before start = %s, want %s", state, ModelServerLoading)
+	}
+
+	go s.Start()
+	defer s.Stop()
+	if err := s.WaitReady(context.Background()); err != nil {
+		t.Fatalf("WaitReady: %v", err)
+	}
+	if !s.IsReady() {
+		t.Error("IsReady = false after WaitReady")
+	}
+	if status := s.Status(); status.State != ModelServerReady || status.ModelVersion != "test" {
+		t.Errorf("status = %+v, want ready with model version test", status)
+	}
+	if analysis, err := s.Analyze(req); err != nil || analysis.Toxicity == nil {
+		t.Errorf("Analyze = %+v, %v", analysis, err)
+	}
+}
+
+func TestModelServerWaitReadyLoadFailure(t *testing.T) {
+	s := NewModelServer(zap.NewNop().Sugar(), "", filepath.Join(t.TempDir(), "missing"), 0)
+	go s.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := s.WaitReady(ctx)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitReady = %v, want load error", err)
+	}
+	if s.IsReady() {
+		t.Error("IsReady = true after load failure")
+	}
+	if status := s.Status(); status.State != ModelServerFailed || status.LoadError == "" {
+		t.Errorf("status = %+v, want failed with load error", status)
+	}
+}
+
+func TestModelServerServeError(t *testing.T) {
+	// 端口被占用时HTTP服务启动失败，模型仍可在进程内使用
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	s := NewModelServer(zap.NewNop().Sugar(), "", trainTestModel(t), l.Addr().(*net.TCPAddr).Port)
+	if err := s.Start(); err == nil {
+		t.Fatal("Start succeeded on a port in use")
+	}
+	if status := s.Status(); status.State != ModelServerReady || status.ServeError == "" {
+		t.Errorf("status = %+v, want ready with serve error", status)
+	}
+}
+
+func TestHealthCheckReportsModelServer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	loaded := NewModelServer(zap.NewNop().Sugar(), "", trainTestModel(t), 0)
+	go loaded.Start()
+	defer loaded.Stop()
+	if err := loaded.WaitReady(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	failed := NewModelServer(zap.NewNop().Sugar(), "", filepath.Join(t.TempDir(), "missing"), 0)
+	failed.Start()
+
+	tests := []struct {
+		name        string
+		modelServer *ModelServer
+		wantStatus  string
+		wantState   string
+	}{
+		{"not running in process", nil, "ok", ""},
+		{"loading", NewModelServer(zap.NewNop().Sugar(), "", "", 0), "degraded", ModelServerLoading},
+		{"ready", loaded, "ok", ModelServerReady},
+		{"load failed", failed, "degraded", ModelServerFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestCheckService(t, nil)
+			service.modelServer = tt.modelServer
+			engine := gin.New()
+			RegisterHTTPHandlers(engine, service)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+			var resp struct {
+				Status      string             `json:"status"`
+				ModelServer *ModelServerStatus `json:"model_server"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", resp.Status, tt.wantStatus)
+			}
+			var state string
+			if resp.ModelServer != nil {
+				state = resp.ModelServer.State
+			}
+			if state != tt.wantState {
+				t.Errorf("model_server state = %q, want %q", state, tt.wantState)
+			}
+		})
+	}
+}
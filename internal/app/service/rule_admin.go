@@ -0,0 +1,193 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ListRules 按规则文件中的顺序返回当前规则集的所有规则
+func (e *RuleEngine) ListRules() ([]*Rule, error) {
+	doc, err := e.currentDocument()
+	if err != nil {
+		return nil, err
+	}
+	return doc.Rules, nil
+}
+
+// GetRule 获取单条规则
+func (e *RuleEngine) GetRule(id string) (*Rule, error) {
+	doc, err := e.currentDocument()
+	if err != nil {
+		return nil, err
+	}
+	if i := doc.indexOf(id); i >= 0 {
+		return doc.Rules[i], nil
+	}
+	return nil, ErrRuleNotFound
+}
+
+// CreateRule 新增规则，规则ID已存在时返回错误
+func (e *RuleEngine) CreateRule(rule *Rule, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("%w: rule is required", ErrInvalidRequest)
+	}
+	return e.applyChange(author, comment, func(doc *ruleDocument) error {
+		if doc.indexOf(rule.ID) >= 0 {
+			return fmt.Errorf("%w: rule %q already exists", ErrInvalidRequest, rule.ID)
+		}
+		doc.Rules = append(doc.Rules, rule)
+		return nil
+	})
+}
+
+// UpdateRule 整体替换规则
+func (e *RuleEngine) UpdateRule(id string, rule *Rule, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("%w: rule is required", ErrInvalidRequest)
+	}
+	if rule.ID == "" {
+		rule.ID = id
+	}
+	if rule.ID != id {
+		return nil, fmt.Errorf("%w: rule id cannot be changed", ErrInvalidRequest)
+	}
+	return e.applyChange(author, comment, func(doc *ruleDocument) error {
+		i := doc.indexOf(id)
+		if i < 0 {
+			return ErrRuleNotFound
+		}
+		doc.Rules[i] = rule
+		return nil
+	})
+}
+
+// SetRuleEnabled 启用或禁用规则
+func (e *RuleEngine) SetRuleEnabled(id string, enabled bool, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	return e.applyChange(author, comment, func(doc *ruleDocument) error {
+		i := doc.indexOf(id)
+		if i < 0 {
+			return ErrRuleNotFound
+		}
+		doc.Rules[i].Enabled = enabled
+		return nil
+	})
+}
+
+// DeleteRule 删除规则
+func (e *RuleEngine) DeleteRule(id string, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	return e.applyChange(author, comment, func(doc *ruleDocument) error {
+		i := doc.indexOf(id)
+		if i < 0 {
+			return ErrRuleNotFound
+		}
+		doc.Rules = append(doc.Rules[:i], doc.Rules[i+1:]...)
+		return nil
+	})
+}
+
+// ListVersions 返回规则集版本列表（不含规则内容），最新的版本在前
+func (e *RuleEngine) ListVersions() []*RuleSetVersion {
+	return e.history.list()
+}
+
+// GetVersion 获取指定版本，包含该版本的完整规则集
+func (e *RuleEngine) GetVersion(version string) (*RuleSetVersion, bool) {
+	v := e.history.get(version)
+	return v, v != nil
+}
+
+// Rollback 回滚到指定版本，回滚本身会以该版本的内容生成一个新版本
+func (e *RuleEngine) Rollback(version string, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	if author.Identity == "" {
+		return nil, fmt.Errorf("%w: author is required", ErrInvalidRequest)
+	}
+	target := e.history.get(version)
+	if target == nil {
+		return nil, fmt.Errorf("%w: version %q not found", ErrInvalidRequest, version)
+	}
+	if comment == "" {
+		comment = "rollback to " + version
+	}
+
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+
+	ruleSet, err := parseRuleSet(target.RuleSet)
+	if err != nil {
+		return nil, fmt.Errorf("version %s is invalid: %w", version, err)
+	}
+	return e.commit(ruleSet, target.RuleSet, author, comment, "rollback")
+}
+
+// applyChange 在当前规则集的副本上执行变更，校验通过后写回规则文件并记录新版本
+func (e *RuleEngine) applyChange(author RuleAuthor, comment string, change func(doc *ruleDocument) error) (*RuleSetVersion, error) {
+	if author.Identity == "" {
+		return nil, fmt.Errorf("%w: author is required", ErrInvalidRequest)
+	}
+
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+
+	doc, err := e.currentDocument()
+	if err != nil {
+		return nil, err
+	}
+	if err := change(doc); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	data = append(data, '\n')
+
+	ruleSet, err := parseRuleSet(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return e.commit(ruleSet, data, author, comment, "admin")
+}
+
+// commit 写回规则文件、记录版本并切换规则集，调用方需持有reloadMu
+func (e *RuleEngine) commit(ruleSet *RuleSet, data []byte, author RuleAuthor, comment, reason string) (*RuleSetVersion, error) {
+	// 先写文件，文件监听触发的重载会因校验和相同而跳过
+	if err := writeFileAtomic(e.ruleFile, data); err != nil {
+		return nil, err
+	}
+
+	version, err := e.history.record(data, author, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record rule version: %w", err)
+	}
+
+	e.install(ruleSet, version, reason)
+	return version, nil
+}
+
+// currentDocument 解析当前规则集的原始内容，返回可修改的副本
+func (e *RuleEngine) currentDocument() (*ruleDocument, error) {
+	e.mu.RLock()
+	ruleSet := e.ruleSet
+	e.mu.RUnlock()
+
+	if ruleSet == nil {
+		return nil, fmt.Errorf("rule engine not initialized")
+	}
+
+	var doc ruleDocument
+	if err := json.Unmarshal(ruleSet.data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule data: %w", err)
+	}
+	return &doc, nil
+}
+
+// indexOf 返回规则在文件中的位置，不存在时返回-1
+func (d *ruleDocument) indexOf(id string) int {
+	for i, rule := range d.Rules {
+		if rule != nil && rule.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+)
+
+// newTestRuleEngine 创建使用临时规则文件及版本历史目录的规则引擎
+func newTestRuleEngine(t *testing.T) (engine *RuleEngine, ruleFile, historyDir string) {
+	t.Helper()
+	dir := t.TempDir()
+	ruleFile = filepath.Join(dir, "rules.json")
+	historyDir = filepath.Join(dir, "versions")
+	if err := os.WriteFile(ruleFile, testRuleFile("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := NewRuleEngine(ruleFile, historyDir, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine, ruleFile, historyDir
+}
+
+// testRule 返回一条可通过校验的规则
+func testRule(id string) *Rule {
+	return &Rule{
+		ID:        id,
+		Enabled:   true,
+		Action:    RuleActionReview,
+		Score:     50,
+		Condition: &Condition{Field: FieldContent, Op: OpContains, Value: id},
+	}
+}
+
+func TestRuleAdminChanges(t *testing.T) {
+	author := RuleAuthor{Identity: "admin:test", Claimed: "alice"}
+
+	tests := []struct {
+		name    string
+		change  func(e *RuleEngine) (*RuleSetVersion, error)
+		wantErr error
+		wantIDs []string
+	}{
+		{
+			name:    "create",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.CreateRule(testRule("second"), author, "") },
+			wantIDs: []string{"first", "second"},
+		},
+		{
+			name:    "create duplicate",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.CreateRule(testRule("first"), author, "") },
+			wantErr: ErrInvalidRequest,
+			wantIDs: []string{"first"},
+		},
+		{
+			name: "create invalid",
+			change: func(e *RuleEngine) (*RuleSetVersion, error) {
+				rule := testRule("second")
+				rule.Action = "delete"
+				return e.CreateRule(rule, author, "")
+			},
+			wantErr: ErrInvalidRequest,
+			wantIDs: []string{"first"},
+		},
+		{
+			name: "create without identity",
+			change: func(e *RuleEngine) (*RuleSetVersion, error) {
+				return e.CreateRule(testRule("second"), RuleAuthor{Claimed: "alice"}, "")
+			},
+			wantErr: ErrInvalidRequest,
+			wantIDs: []string{"first"},
+		},
+		{
+			name:    "update",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.UpdateRule("first", testRule(""), author, "") },
+			wantIDs: []string{"first"},
+		},
+		{
+			name: "update changes id",
+			change: func(e *RuleEngine) (*RuleSetVersion, error) {
+				return e.UpdateRule("first", testRule("other"), author, "")
+			},
+			wantErr: ErrInvalidRequest,
+			wantIDs: []string{"first"},
+		},
+		{
+			name: "update missing",
+			change: func(e *RuleEngine) (*RuleSetVersion, error) {
+				return e.UpdateRule("missing", testRule("missing"), author, "")
+			},
+			wantErr: ErrRuleNotFound,
+			wantIDs: []string{"first"},
+		},
+		{
+			name:    "disable",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.SetRuleEnabled("first", false, author, "") },
+			wantIDs: []string{"first"},
+		},
+		{
+			name:    "delete",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.DeleteRule("first", author, "") },
+			wantIDs: []string{},
+		},
+		{
+			name:    "delete missing",
+			change:  func(e *RuleEngine) (*RuleSetVersion, error) { return e.DeleteRule("missing", author, "") },
+			wantErr: ErrRuleNotFound,
+			wantIDs: []string{"first"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, ruleFile, _ := newTestRuleEngine(t)
+			before := engine.Version()
+
+			version, err := tt.change(engine)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if engine.Version() != before || len(engine.ListVersions()) != 1 {
+					t.Errorf("failed change created version %s", engine.Version())
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if engine.Version() != version.Version || version.Version == before {
+					t.Errorf("current version = %s, want new version %s", engine.Version(), version.Version)
+				}
+				if version.Author != "admin:test" || version.ClaimedAuthor != "alice" {
+					t.Errorf("author = %q claimed %q, want admin:test claimed alice", version.Author, version.ClaimedAuthor)
+				}
+			}
+
+			rules, err := engine.ListRules()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rules) != len(tt.wantIDs) {
+				t.Fatalf("got %d rules, want %v", len(rules), tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if rules[i].ID != id {
+					t.Errorf("rule %d = %s, want %s", i, rules[i].ID, id)
+				}
+			}
+
+			// 规则文件与当前规则集一致
+			data, err := os.ReadFile(ruleFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ruleSetChecksum(data) != engine.ruleSet.Checksum {
+				t.Error("rule file does not match the current rule set")
+			}
+		})
+	}
+}
+
+func TestRuleAdminRollback(t *testing.T) {
+	engine, _, historyDir := newTestRuleEngine(t)
+	author := RuleAuthor{Identity: "admin:test"}
+	initial := engine.Version()
+
+	if _, err := engine.CreateRule(testRule("second"), author, "add second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.DeleteRule("first", author, "remove first"); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := engine.ListVersions()
+	if len(versions) != 3 || versions[0].Version != engine.Version() || versions[2].Version != initial {
+		t.Fatalf("versions = %+v, want 3 versions newest first", versions)
+	}
+	for _, v := range versions {
+		if v.RuleSet != nil {
+			t.Errorf("version list includes rule set of %s", v.Version)
+		}
+	}
+	if v, ok := engine.GetVersion(initial); !ok || len(v.RuleSet) == 0 || v.Author != ruleFileAuthor {
+		t.Fatalf("GetVersion(%s) = %+v, %v", initial, v, ok)
+	}
+
+	if _, err := engine.Rollback("missing", author, ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("rollback to missing version: err = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := engine.Rollback(initial, RuleAuthor{}, ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("rollback without identity: err = %v, want ErrInvalidRequest", err)
+	}
+
+	rolledBack, err := engine.Rollback(initial, author, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Version == initial || rolledBack.Comment != "rollback to "+initial {
+		t.Errorf("rollback version = %+v, want a new version commented with the target", rolledBack)
+	}
+	if v, _ := engine.GetVersion(initial); rolledBack.Checksum != v.Checksum {
+		t.Error("rolled back rule set differs from the target version")
+	}
+	if _, err := engine.GetRule("first"); err != nil {
+		t.Errorf("rule first not restored: %v", err)
+	}
+	if _, err := engine.GetRule("second"); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("rule second still present after rollback: %v", err)
+	}
+
+	// 版本历史持久化，重启后仍可查询
+	history, err := newRuleHistory(historyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(history.list()); got != 4 {
+		t.Errorf("persisted %d versions, want 4", got)
+	}
+}
+
+func TestRuleAdminAuthorFromAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine, _, _ := newTestRuleEngine(t)
+	cfg := &config.Config{}
+	cfg.Admin.Enabled = true
+	cfg.Admin.Token = "secret"
+	router := gin.New()
+	RegisterHTTPHandlers(router, &ContentCheckService{cfg: cfg, ruleEngine: engine})
+
+	tests := []struct {
+		name        string
+		path        string
+		body        interface{}
+		wantClaimed string
+	}{
+		{"create", "/api/v1/admin/rules", HTTPRuleChangeRequest{Rule: testRule("second"), Author: "mallory"}, "mallory"},
+		{"rollback", "/api/v1/admin/rules/rollback", HTTPRollbackRequest{Version: engine.Version(), Author: "mallory"}, "mallory"},
+		{"without claimed author", "/api/v1/admin/rules/first/disable", HTTPRuleChangeRequest{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer secret")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+
+			// 版本作者取自鉴权结果，请求中的author只作为声明的作者记录
+			latest := engine.ListVersions()[0]
+			if latest.Author != adminIdentity("secret") {
+				t.Errorf("author = %q, want authenticated identity %q", latest.Author, adminIdentity("secret"))
+			}
+			if latest.ClaimedAuthor != tt.wantClaimed {
+				t.Errorf("claimed author = %q, want %q", latest.ClaimedAuthor, tt.wantClaimed)
+			}
+		})
+	}
+}
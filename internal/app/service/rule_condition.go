@@ -0,0 +1,444 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// 条件运算符
+const (
+	OpEq          = "eq"           // 等于
+	OpNe          = "ne"           // 不等于
+	OpGt          = "gt"           // 大于
+	OpGte         = "gte"          // 大于等于
+	OpLt          = "lt"           // 小于
+	OpLte         = "lte"          // 小于等于
+	OpIn          = "in"           // 属于value列表
+	OpNotIn       = "not_in"       // 不属于value列表
+	OpContains    = "contains"     // 字符串包含子串，列表包含元素
+	OpContainsAny = "contains_any" // 包含value列表中任一项
+	OpRegex       = "regex"        // 正则匹配
+	OpExists      = "exists"       // 字段存在（value为false时表示不存在）
+)
+
+// 条件字段，extra.<key> 与 risk.<risk_type> 为前缀字段
+const (
+	FieldContent           = "content"            // 原始内容
+	FieldNormalizedContent = "normalized_content" // 归一化后的内容
+	FieldContentLength     = "content_length"     // 内容长度（字符数）
+	FieldScene             = "scene"              // 场景
+	FieldUserID            = "user_id"            // 用户ID
+	FieldRiskTypes         = "risk_types"         // 检测器命中的风险类型列表
+	FieldRiskCount         = "risk_count"         // 检测器命中的风险项数量
+	FieldMaxRiskScore      = "max_risk_score"     // 检测器风险项的最高分
+	FieldContextCount      = "context.count"      // 上下文条数
+	FieldContextSameUser   = "context.same_user"  // 上下文中当前用户发送的条数
+	FieldContextOtherUser  = "context.other_user" // 上下文中其他用户发送的条数
+
+	fieldExtraPrefix = "extra."
+	fieldRiskPrefix  = "risk."
+)
+
+// Condition 规则条件，all/any/not 为组合条件，field/op/value 为叶子条件，二者互斥
+type Condition struct {
+	All   []*Condition `json:"all,omitempty"`
+	Any   []*Condition `json:"any,omitempty"`
+	Not   *Condition   `json:"not,omitempty"`
+	Field string       `json:"field,omitempty"`
+	Op    string       `json:"op,omitempty"`
+	Value interface{}  `json:"value,omitempty"`
+
+	// 编译结果
+	regex   *regexp.Regexp
+	number  float64
+	text    string
+	list    []string
+	boolean bool
+}
+
+// conditionEnv 条件求值环境，每次评估构建一次
+type conditionEnv struct {
+	ctx        *model.CheckContext
+	riskScores map[string]float32 // 风险类型名 -> 最高分
+	riskTypes  []string
+	riskCount  int
+	maxScore   float32
+}
+
+// newConditionEnv 构建条件求值环境
+func newConditionEnv(ctx *model.CheckContext, risks []*model.RiskItem) *conditionEnv {
+	env := &conditionEnv{
+		ctx:        ctx,
+		riskScores: make(map[string]float32),
+		riskCount:  len(risks),
+	}
+	for _, risk := range risks {
+		name := risk.Type.String()
+		score, ok := env.riskScores[name]
+		if !ok {
+			env.riskTypes = append(env.riskTypes, name)
+		}
+		if !ok || risk.Score > score {
+			env.riskScores[name] = risk.Score
+		}
+		if risk.Score > env.maxScore {
+			env.maxScore = risk.Score
+		}
+	}
+	return env
+}
+
+// Compile 校验条件并预编译正则及比较值
+func (c *Condition) Compile() error {
+	kinds := 0
+	if len(c.All) > 0 {
+		kinds++
+	}
+	if len(c.Any) > 0 {
+		kinds++
+	}
+	if c.Not != nil {
+		kinds++
+	}
+	if c.Field != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return fmt.Errorf("condition must have exactly one of all, any, not or field")
+	}
+
+	for _, sub := range append(append([]*Condition{}, c.All...), c.Any...) {
+		if sub == nil {
+			return fmt.Errorf("empty sub condition")
+		}
+		if err := sub.Compile(); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		return c.Not.Compile()
+	}
+	if c.Field == "" {
+		return nil
+	}
+
+	if !isKnownField(c.Field) {
+		return fmt.Errorf("unknown field %q", c.Field)
+	}
+
+	switch c.Op {
+	case OpEq, OpNe, OpContains:
+		c.text = fmt.Sprint(c.Value)
+		c.number, _ = toNumber(c.Value)
+	case OpGt, OpGte, OpLt, OpLte:
+		n, ok := toNumber(c.Value)
+		if !ok {
+			return fmt.Errorf("field %q: %s requires a numeric value", c.Field, c.Op)
+		}
+		c.number = n
+	case OpIn, OpNotIn, OpContainsAny:
+		list, ok := toStringList(c.Value)
+		if !ok {
+			return fmt.Errorf("field %q: %s requires a list value", c.Field, c.Op)
+		}
+		c.list = list
+	case OpRegex:
+		pattern, ok := c.Value.(string)
+		if !ok {
+			return fmt.Errorf("field %q: regex requires a string value", c.Field)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("field %q: invalid regex: %w", c.Field, err)
+		}
+		c.regex = re
+	case OpExists:
+		c.boolean = true
+		if b, ok := c.Value.(bool); ok {
+			c.boolean = b
+		}
+	default:
+		return fmt.Errorf("field %q: unknown op %q", c.Field, c.Op)
+	}
+
+	return nil
+}
+
+// collectFields 收集条件及子条件引用的字段
+func (c *Condition) collectFields(fields map[string]bool) {
+	if c == nil {
+		return
+	}
+	for _, sub := range c.All {
+		sub.collectFields(fields)
+	}
+	for _, sub := range c.Any {
+		sub.collectFields(fields)
+	}
+	c.Not.collectFields(fields)
+	if c.Field != "" {
+		fields[c.Field] = true
+	}
+}
+
+// Evaluate 对条件求值
+func (c *Condition) Evaluate(env *conditionEnv) bool {
+	switch {
+	case len(c.All) > 0:
+		for _, sub := range c.All {
+			if !sub.Evaluate(env) {
+				return false
+			}
+		}
+		return true
+	case len(c.Any) > 0:
+		for _, sub := range c.Any {
+			if sub.Evaluate(env) {
+				return true
+			}
+		}
+		return false
+	case c.Not != nil:
+		return !c.Not.Evaluate(env)
+	}
+
+	value, exists := env.lookup(c.Field)
+	if c.Op == OpExists {
+		return exists == c.boolean
+	}
+	if !exists {
+		// 不存在的字段只满足否定类运算
+		return c.Op == OpNe || c.Op == OpNotIn
+	}
+
+	switch v := value.(type) {
+	case []string:
+		return c.evaluateList(v)
+	case float64:
+		return c.evaluateNumber(v)
+	default:
+		return c.evaluateString(fmt.Sprint(v))
+	}
+}
+
+// maxExplainValueLen 解释信息中字段实际值的最大长度（字符数）
+const maxExplainValueLen = 50
+
+// Explain 对条件求值并给出原因：all不满足时为首个不满足的子条件，
+// any满足时为首个满足的子条件，其余情况列出全部子条件
+func (c *Condition) Explain(env *conditionEnv) (bool, string) {
+	switch {
+	case len(c.All) > 0:
+		reasons := make([]string, 0, len(c.All))
+		for _, sub := range c.All {
+			matched, reason := sub.Explain(env)
+			if !matched {
+				return false, reason
+			}
+			reasons = append(reasons, reason)
+		}
+		return true, strings.Join(reasons, " AND ")
+	case len(c.Any) > 0:
+		reasons := make([]string, 0, len(c.Any))
+		for _, sub := range c.Any {
+			matched, reason := sub.Explain(env)
+			if matched {
+				return true, reason
+			}
+			reasons = append(reasons, reason)
+		}
+		return false, "(" + strings.Join(reasons, " OR ") + ")"
+	case c.Not != nil:
+		matched, reason := c.Not.Explain(env)
+		return !matched, "NOT (" + reason + ")"
+	}
+
+	actual := "<missing>"
+	if value, exists := env.lookup(c.Field); exists {
+		actual = fmt.Sprint(value)
+		if runes := []rune(actual); len(runes) > maxExplainValueLen {
+			actual = string(runes[:maxExplainValueLen]) + "..."
+		}
+	}
+	return c.Evaluate(env), fmt.Sprintf("%s %s %v [actual: %s]", c.Field, c.Op, c.Value, actual)
+}
+
+// evaluateString 字符串字段求值，数值比较时尝试将字段解析为数字
+func (c *Condition) evaluateString(s string) bool {
+	switch c.Op {
+	case OpEq:
+		return s == c.text
+	case OpNe:
+		return s != c.text
+	case OpGt, OpGte, OpLt, OpLte:
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return err == nil && c.evaluateNumber(n)
+	case OpIn:
+		return containsString(c.list, s)
+	case OpNotIn:
+		return !containsString(c.list, s)
+	case OpContains:
+		return strings.Contains(s, c.text)
+	case OpContainsAny:
+		for _, item := range c.list {
+			if strings.Contains(s, item) {
+				return true
+			}
+		}
+		return false
+	case OpRegex:
+		return c.regex.MatchString(s)
+	}
+	return false
+}
+
+// evaluateNumber 数值字段求值
+func (c *Condition) evaluateNumber(n float64) bool {
+	switch c.Op {
+	case OpEq:
+		return n == c.number
+	case OpNe:
+		return n != c.number
+	case OpGt:
+		return n > c.number
+	case OpGte:
+		return n >= c.number
+	case OpLt:
+		return n < c.number
+	case OpLte:
+		return n <= c.number
+	}
+	return c.evaluateString(strconv.FormatFloat(n, 'f', -1, 64))
+}
+
+// evaluateList 列表字段求值
+func (c *Condition) evaluateList(values []string) bool {
+	switch c.Op {
+	case OpContains:
+		return containsString(values, c.text)
+	case OpContainsAny:
+		for _, item := range c.list {
+			if containsString(values, item) {
+				return true
+			}
+		}
+		return false
+	case OpEq, OpGt, OpGte, OpLt, OpLte, OpNe:
+		// 与列表长度比较
+		return c.evaluateNumber(float64(len(values)))
+	}
+	return false
+}
+
+// lookup 读取字段值，返回值为string、float64或[]string
+func (env *conditionEnv) lookup(field string) (interface{}, bool) {
+	ctx := env.ctx
+	switch field {
+	case FieldContent:
+		return ctx.Content, true
+	case FieldNormalizedContent:
+		return ctx.DetectContent(), true
+	case FieldContentLength:
+		return float64(utf8.RuneCountInString(ctx.Content)), true
+	case FieldScene:
+		return ctx.Scene, true
+	case FieldUserID:
+		return ctx.UserID, true
+	case FieldRiskTypes:
+		return env.riskTypes, true
+	case FieldRiskCount:
+		return float64(env.riskCount), true
+	case FieldMaxRiskScore:
+		return float64(env.maxScore), true
+	case FieldContextCount:
+		return float64(len(ctx.ContextItems)), true
+	case FieldContextSameUser, FieldContextOtherUser:
+		same := 0
+		for _, item := range ctx.ContextItems {
+			if item.UserID == ctx.UserID {
+				same++
+			}
+		}
+		if field == FieldContextSameUser {
+			return float64(same), true
+		}
+		return float64(len(ctx.ContextItems) - same), true
+	}
+
+	if key, ok := strings.CutPrefix(field, fieldExtraPrefix); ok {
+		value, exists := ctx.ExtraData[key]
+		return value, exists
+	}
+	if name, ok := strings.CutPrefix(field, fieldRiskPrefix); ok {
+		score, exists := env.riskScores[name]
+		return float64(score), exists
+	}
+
+	return nil, false
+}
+
+// isKnownField 判断字段是否受支持
+func isKnownField(field string) bool {
+	switch field {
+	case FieldContent, FieldNormalizedContent, FieldContentLength, FieldScene, FieldUserID,
+		FieldRiskTypes, FieldRiskCount, FieldMaxRiskScore,
+		FieldContextCount, FieldContextSameUser, FieldContextOtherUser:
+		return true
+	}
+	if key, ok := strings.CutPrefix(field, fieldExtraPrefix); ok {
+		return key != ""
+	}
+	if name, ok := strings.CutPrefix(field, fieldRiskPrefix); ok {
+		_, known := model.ParseRiskType(name)
+		return known
+	}
+	return false
+}
+
+// toNumber 将JSON值转换为数字
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toStringList 将JSON数组转换为字符串列表
+func toStringList(v interface{}) ([]string, bool) {
+	switch list := v.(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// containsString 判断列表是否包含s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
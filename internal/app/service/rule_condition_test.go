@@ -0,0 +1,148 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// parseCondition 解析并编译JSON条件
+func parseCondition(t *testing.T, data string) (*Condition, error) {
+	t.Helper()
+	var c Condition
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("unmarshal condition %s: %v", data, err)
+	}
+	return &c, c.Compile()
+}
+
+func TestConditionCompileErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   string
+	}{
+		{"empty", `{}`, "exactly one of"},
+		{"field and all", `{"field":"content","op":"eq","value":"a","all":[{"field":"scene","op":"eq","value":"chat"}]}`, "exactly one of"},
+		{"nil sub condition", `{"any":[null]}`, "empty sub condition"},
+		{"unknown field", `{"field":"body","op":"eq","value":"a"}`, "unknown field"},
+		{"empty extra key", `{"field":"extra.","op":"exists"}`, "unknown field"},
+		{"unknown risk type", `{"field":"risk.porn","op":"gte","value":50}`, "unknown field"},
+		{"unknown op", `{"field":"content","op":"like","value":"a"}`, "unknown op"},
+		{"non numeric comparison", `{"field":"content_length","op":"gt","value":"long"}`, "numeric value"},
+		{"in requires list", `{"field":"scene","op":"in","value":"chat"}`, "list value"},
+		{"regex requires string", `{"field":"content","op":"regex","value":1}`, "string value"},
+		{"invalid regex", `{"field":"content","op":"regex","value":"("}`, "invalid regex"},
+		{"nested error", `{"not":{"all":[{"field":"scene","op":"eq","value":"chat"},{"field":"x","op":"eq","value":1}]}}`, "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCondition(t, tt.condition)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Compile(%s) error = %v, want error containing %q", tt.condition, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConditionEvaluate(t *testing.T) {
+	ctx := &model.CheckContext{
+		Content:           "加我 vx 13800138000",
+		NormalizedContent: "加我vx13800138000",
+		UserID:            "u1",
+		Scene:             "comment",
+		ExtraData:         map[string]string{"reputation": "15", "vip": ""},
+		ContextItems: []*model.ContextItem{
+			{UserID: "u1", Content: "在吗"},
+			{UserID: "u2", Content: "不要再发了"},
+			{UserID: "u1", Content: "加我"},
+		},
+	}
+	env := newConditionEnv(ctx, []*model.RiskItem{
+		model.NewRiskItem(model.RiskTypeSpam, 50, "垃圾信息"),
+		model.NewRiskItem(model.RiskTypeSpam, 70, "垃圾信息"),
+		model.NewRiskItem(model.RiskTypeHarassment, 40, "骚扰"),
+	})
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		// 字符串字段
+		{`{"field":"content","op":"contains","value":"vx"}`, true},
+		{`{"field":"content","op":"regex","value":"\\d{11}"}`, true},
+		{`{"field":"normalized_content","op":"contains","value":"我vx"}`, true},
+		{`{"field":"content","op":"contains_any","value":["微信","vx"]}`, true},
+		{`{"field":"scene","op":"eq","value":"comment"}`, true},
+		{`{"field":"scene","op":"ne","value":"comment"}`, false},
+		{`{"field":"scene","op":"in","value":["chat","comment"]}`, true},
+		{`{"field":"scene","op":"not_in","value":["chat","comment"]}`, false},
+		{`{"field":"user_id","op":"eq","value":"u1"}`, true},
+		// 数值字段
+		{`{"field":"content_length","op":"eq","value":17}`, true},
+		{`{"field":"content_length","op":"gt","value":"16"}`, true},
+		{`{"field":"max_risk_score","op":"gte","value":70}`, true},
+		{`{"field":"risk_count","op":"lt","value":3}`, false},
+		{`{"field":"context.count","op":"eq","value":3}`, true},
+		{`{"field":"context.same_user","op":"eq","value":2}`, true},
+		{`{"field":"context.other_user","op":"eq","value":1}`, true},
+		// 风险字段取同类型的最高分
+		{`{"field":"risk.spam","op":"gte","value":70}`, true},
+		{`{"field":"risk.harassment","op":"gt","value":40}`, false},
+		{`{"field":"risk.violence","op":"lt","value":100}`, false},
+		{`{"field":"risk.violence","op":"exists","value":false}`, true},
+		{`{"field":"risk_types","op":"contains","value":"spam"}`, true},
+		{`{"field":"risk_types","op":"contains_any","value":["violence","harassment"]}`, true},
+		{`{"field":"risk_types","op":"eq","value":2}`, true},
+		// 扩展字段按字符串比较，数值运算解析为数字
+		{`{"field":"extra.reputation","op":"lt","value":20}`, true},
+		{`{"field":"extra.reputation","op":"eq","value":"15"}`, true},
+		{`{"field":"extra.vip","op":"exists"}`, true},
+		{`{"field":"extra.level","op":"exists"}`, false},
+		{`{"field":"extra.level","op":"ne","value":"1"}`, true},
+		{`{"field":"extra.level","op":"not_in","value":["1"]}`, true},
+		{`{"field":"extra.level","op":"eq","value":""}`, false},
+		// 组合条件
+		{`{"all":[{"field":"scene","op":"eq","value":"comment"},{"field":"risk.spam","op":"gte","value":60}]}`, true},
+		{`{"all":[{"field":"scene","op":"eq","value":"comment"},{"field":"risk.spam","op":"gte","value":80}]}`, false},
+		{`{"any":[{"field":"scene","op":"eq","value":"chat"},{"field":"user_id","op":"eq","value":"u1"}]}`, true},
+		{`{"any":[{"field":"scene","op":"eq","value":"chat"},{"field":"user_id","op":"eq","value":"u2"}]}`, false},
+		{`{"not":{"field":"scene","op":"eq","value":"chat"}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			c, err := parseCondition(t, tt.condition)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := c.Evaluate(env); got != tt.want {
+				t.Errorf("Evaluate = %v, want %v", got, tt.want)
+			}
+			if got, reason := c.Explain(env); got != tt.want || reason == "" {
+				t.Errorf("Explain = %v %q, want %v with a reason", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleSetCacheFields(t *testing.T) {
+	ruleSet, err := parseRuleSet([]byte(`{"rules":[
+		{"id":"a","enabled":true,"action":"mark","condition":{"any":[
+			{"field":"extra.level","op":"eq","value":"1"},
+			{"not":{"field":"extra.channel","op":"exists"}}
+		]}},
+		{"id":"b","enabled":false,"action":"none","condition":{"field":"user_id","op":"eq","value":"u1"}},
+		{"id":"c","enabled":true,"action":"none","condition":{"field":"extra.level","op":"ne","value":"2"}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ruleSet.usesUserID {
+		t.Error("usesUserID = false, want true")
+	}
+	if got := strings.Join(ruleSet.extraKeys, ","); got != "channel,level" {
+		t.Errorf("extraKeys = %q, want channel,level", got)
+	}
+}
@@ -1,10 +1,14 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -20,7 +24,11 @@ type Rule struct {
 	Priority    int                    `json:"priority"`
 	Action      string                 `json:"action"`
 	Score       float32                `json:"score"`
+	RiskType    string                 `json:"risk_type"` // 规则命中时生成的风险项类型
+	Condition   *Condition             `json:"condition"` // 命中条件
 	Config      map[string]interface{} `json:"config"`
+
+	riskType model.RiskType
 }
 
 // RuleSet 规则集
@@ -28,21 +36,70 @@ type RuleSet struct {
 	Rules      map[string]*Rule      `json:"rules"`
 	Actions    map[string]RuleAction `json:"actions"`
 	Categories map[string]string     `json:"categories"`
+
+	Version  string    `json:"-"` // 规则集版本号，对应版本历史中的记录
+	Checksum string    `json:"-"`
+	LoadedAt time.Time `json:"-"`
+
+	data   []byte  // 规则文件原始内容
+	sorted []*Rule // 按优先级降序排列的规则
+
+	// 规则条件引用的请求字段，审核结果随这些字段变化，需计入缓存键
+	usesUserID bool
+	extraKeys  []string // extra.<key> 中的key，已排序
+}
+
+// ruleDocument 规则文件结构，规则按文件中的顺序排列
+type ruleDocument struct {
+	Rules      []*Rule               `json:"rules"`
+	Actions    map[string]RuleAction `json:"actions"`
+	Categories map[string]string     `json:"categories"`
 }
 
+// 内置规则动作
+const (
+	RuleActionBlock  = "block"
+	RuleActionReview = "review"
+	RuleActionMark   = "mark"
+	RuleActionNone   = "none"
+)
+
 // RuleAction 规则动作
 type RuleAction struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
+// 规则文件监听状态
+const (
+	RuleWatchDisabled = "disabled" // 未开启监听
+	RuleWatchWatching = "watching" // 正在监听
+	RuleWatchRetrying = "retrying" // 监听出错，等待重新监听
+)
+
+// 规则文件监听出错后重新监听的退避间隔
+var (
+	ruleWatchRetryMin = time.Second
+	ruleWatchRetryMax = time.Minute
+)
+
+// RuleWatchStatus 规则文件监听状态
+type RuleWatchStatus struct {
+	State     string    `json:"state"`
+	Failures  int       `json:"failures"`             // 连续出错次数，恢复监听一段时间后清零
+	LastError string    `json:"last_error,omitempty"` // 最近一次出错原因
+	Since     time.Time `json:"since"`                // 进入当前状态的时间
+}
+
 // RuleEngineResult 规则引擎评估结果
 type RuleEngineResult struct {
 	Result            model.ResultType
 	Score             float32
 	Risks             []*model.RiskItem
 	Suggestion        string
-	HasExplicitResult bool
+	HasExplicitResult bool   // 阻止规则命中，直接作为审核结果，不再按阈值判定
+	RuleID            string // 给出结果的规则，未阻止时为命中规则中动作最严重的一条
+	RuleVersion       string // 本次评估使用的规则集版本
 }
 
 // RuleEngine 规则引擎
@@ -50,14 +107,24 @@ type RuleEngine struct {
 	ruleSet     *RuleSet
 	logger      *zap.SugaredLogger
 	ruleFile    string
+	history     *ruleHistory
 	initialized bool
+	watch       RuleWatchStatus
 	mu          sync.RWMutex
+	reloadMu    sync.Mutex // 串行化规则重载与变更
 }
 
-// NewRuleEngine 创建规则引擎
-func NewRuleEngine(ruleFile string, logger *zap.SugaredLogger) (*RuleEngine, error) {
+// NewRuleEngine 创建规则引擎，historyDir为规则集版本历史目录，为空时版本历史只保存在内存中
+func NewRuleEngine(ruleFile, historyDir string, logger *zap.SugaredLogger) (*RuleEngine, error) {
+	history, err := newRuleHistory(historyDir)
+	if err != nil {
+		return nil, err
+	}
+
 	engine := &RuleEngine{
 		ruleFile: ruleFile,
+		watch:    RuleWatchStatus{State: RuleWatchDisabled, Since: time.Now()},
+		history:  history,
 		logger:   logger,
 	}
 
@@ -68,90 +135,320 @@ func NewRuleEngine(ruleFile string, logger *zap.SugaredLogger) (*RuleEngine, err
 	return engine, nil
 }
 
-// loadRules 加载规则
+// loadRules 加载规则文件，校验通过后原子替换当前规则集；
+// 文件无效时保留上一个有效的规则集并返回错误
 func (e *RuleEngine) loadRules() error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
 
-	data, err := ioutil.ReadFile(e.ruleFile)
+	data, err := os.ReadFile(e.ruleFile)
 	if err != nil {
 		return fmt.Errorf("failed to read rule file: %w", err)
 	}
 
-	var ruleData struct {
-		Rules      []*Rule               `json:"rules"`
-		Actions    map[string]RuleAction `json:"actions"`
-		Categories map[string]string     `json:"categories"`
+	checksum := ruleSetChecksum(data)
+
+	e.mu.RLock()
+	current := e.ruleSet
+	e.mu.RUnlock()
+	if current != nil && current.Checksum == checksum {
+		return nil
+	}
+
+	ruleSet, err := parseRuleSet(data)
+	if err != nil {
+		return fmt.Errorf("invalid rule file %s: %w", e.ruleFile, err)
+	}
+
+	// 文件内容与最近一次记录的版本相同时沿用该版本，否则记录为新版本
+	version := e.history.latest()
+	if version == nil || version.Checksum != checksum {
+		version, err = e.history.record(data, RuleAuthor{Identity: ruleFileAuthor}, "loaded from "+e.ruleFile)
+		if err != nil {
+			return fmt.Errorf("failed to record rule version: %w", err)
+		}
 	}
 
+	e.install(ruleSet, version, "file")
+	return nil
+}
+
+// install 切换到新的规则集，调用方需持有reloadMu
+func (e *RuleEngine) install(ruleSet *RuleSet, version *RuleSetVersion, reason string) {
+	ruleSet.Version = version.Version
+	ruleSet.Checksum = version.Checksum
+	ruleSet.LoadedAt = time.Now()
+
+	e.mu.Lock()
+	current := e.ruleSet
+	e.ruleSet = ruleSet
+	e.initialized = true
+	e.mu.Unlock()
+
+	previous := ""
+	if current != nil {
+		previous = current.Version
+	}
+	e.logger.Infow("Rules loaded",
+		"file", e.ruleFile,
+		"reason", reason,
+		"version", ruleSet.Version,
+		"previous_version", previous,
+		"author", version.Author,
+		"count", len(ruleSet.Rules),
+	)
+}
+
+// parseRuleSet 解析并校验规则集：规则ID不能为空或重复，动作必须为内置动作，
+// 条件及风险类型必须有效，任一规则无效时整个规则集无效
+func parseRuleSet(data []byte) (*RuleSet, error) {
+	var ruleData ruleDocument
 	if err := json.Unmarshal(data, &ruleData); err != nil {
-		return fmt.Errorf("failed to unmarshal rule data: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal rule data: %w", err)
 	}
 
 	ruleSet := &RuleSet{
 		Rules:      make(map[string]*Rule),
 		Actions:    ruleData.Actions,
 		Categories: ruleData.Categories,
+		data:       data,
 	}
 
-	for _, rule := range ruleData.Rules {
+	for i, rule := range ruleData.Rules {
+		if rule == nil || rule.ID == "" {
+			return nil, fmt.Errorf("rule %d: id is required", i)
+		}
+		if _, ok := ruleSet.Rules[rule.ID]; ok {
+			return nil, fmt.Errorf("duplicate rule id %q", rule.ID)
+		}
+		switch rule.Action {
+		case RuleActionBlock, RuleActionReview, RuleActionMark, RuleActionNone:
+		default:
+			return nil, fmt.Errorf("rule %s: unknown action %q", rule.ID, rule.Action)
+		}
+		if err := compileRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
 		ruleSet.Rules[rule.ID] = rule
+		ruleSet.sorted = append(ruleSet.sorted, rule)
 	}
 
-	e.ruleSet = ruleSet
-	e.initialized = true
+	// 按优先级排序（降序），同优先级保持文件中的顺序
+	sort.SliceStable(ruleSet.sorted, func(i, j int) bool {
+		return ruleSet.sorted[i].Priority > ruleSet.sorted[j].Priority
+	})
 
-	e.logger.Infof("Loaded %d rules from %s", len(ruleSet.Rules), e.ruleFile)
-	return nil
+	// 场景覆盖可以启用被禁用的规则，禁用规则引用的字段同样计入
+	fields := make(map[string]bool)
+	for _, rule := range ruleSet.sorted {
+		rule.Condition.collectFields(fields)
+	}
+	for field := range fields {
+		if field == FieldUserID {
+			ruleSet.usesUserID = true
+		} else if key, ok := strings.CutPrefix(field, fieldExtraPrefix); ok {
+			ruleSet.extraKeys = append(ruleSet.extraKeys, key)
+		}
+	}
+	sort.Strings(ruleSet.extraKeys)
+
+	return ruleSet, nil
 }
 
-// Evaluate 评估内容
-func (e *RuleEngine) Evaluate(ctx *model.CheckContext, existingRisks []*model.RiskItem) (*RuleEngineResult, error) {
+// Version 返回当前规则集版本
+func (e *RuleEngine) Version() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.ruleSet == nil {
+		return ""
+	}
+	return e.ruleSet.Version
+}
+
+// CacheScope 返回当前规则集版本及规则条件引用的请求字段（用户ID、extra.<key>），用于生成审核结果的缓存键
+func (e *RuleEngine) CacheScope() (version string, usesUserID bool, extraKeys []string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.ruleSet == nil {
+		return "", false, nil
+	}
+	return e.ruleSet.Version, e.ruleSet.usesUserID, e.ruleSet.extraKeys
+}
+
+// Start 按interval定时重载规则文件，watch为true时同时监听文件变更，ctx结束前阻塞
+func (e *RuleEngine) Start(ctx context.Context, interval time.Duration, watch bool) {
+	reload := func() {
+		if err := e.loadRules(); err != nil {
+			e.logger.Errorf("Failed to reload rules, keep version %s: %v", e.Version(), err)
+		}
+	}
+
+	if watch {
+		watchDone := make(chan struct{})
+		go func() {
+			defer close(watchDone)
+			e.watchRules(ctx, reload)
+		}()
+		defer func() { <-watchDone }()
+	}
+
+	if interval <= 0 {
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reload()
+		}
+	}
+}
+
+// watchRules 持续监听规则文件，ctx结束前阻塞。监听出错时按退避间隔重新监听，
+// 并在恢复后重新加载一次以补上中断期间的变更
+func (e *RuleEngine) watchRules(ctx context.Context, reload func()) {
+	backoff := ruleWatchRetryMin
+	for {
+		started := time.Now()
+		e.setWatchState(RuleWatchWatching, nil)
+		err := watchFile(ctx, e.ruleFile, reload)
+		if ctx.Err() != nil {
+			e.setWatchState(RuleWatchDisabled, nil)
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("file watcher closed")
+		}
+
+		// 监听持续了较长时间后才出错，重新从最短间隔开始退避并重新计数
+		if time.Since(started) > ruleWatchRetryMax {
+			backoff = ruleWatchRetryMin
+			e.mu.Lock()
+			e.watch.Failures = 0
+			e.mu.Unlock()
+		}
+		e.setWatchState(RuleWatchRetrying, err)
+		e.logger.Errorf("Watching rule file %s failed, retry in %s: %v", e.ruleFile, backoff, err)
+		select {
+		case <-ctx.Done():
+			e.setWatchState(RuleWatchDisabled, nil)
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > ruleWatchRetryMax {
+			backoff = ruleWatchRetryMax
+		}
+		reload()
+	}
+}
+
+// setWatchState 更新监听状态，err不为nil时累计连续出错次数
+func (e *RuleEngine) setWatchState(state string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		e.watch.Failures++
+		e.watch.LastError = err.Error()
+	} else if state != RuleWatchWatching {
+		e.watch.Failures = 0
+		e.watch.LastError = ""
+	}
+	if e.watch.State != state {
+		e.watch.State = state
+		e.watch.Since = time.Now()
+	}
+}
+
+// WatchStatus 返回规则文件监听状态
+func (e *RuleEngine) WatchStatus() RuleWatchStatus {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
+	return e.watch
+}
 
-	if !e.initialized {
-		return nil, fmt.Errorf("rule engine not initialized")
+// RuleEvalOptions 单次评估的选项
+type RuleEvalOptions struct {
+	RuleSet   *RuleSet                // 候选规则集，为nil时使用当前规则集
+	Overrides map[string]RuleOverride // 场景对规则的覆盖
+	Trace     *model.CheckTrace       // 不为nil时记录每条规则的求值过程
+}
+
+// Evaluate 评估内容
+func (e *RuleEngine) Evaluate(ctx *model.CheckContext, existingRisks []*model.RiskItem) (*RuleEngineResult, error) {
+	return e.EvaluateWith(ctx, existingRisks, RuleEvalOptions{})
+}
+
+// EvaluateWith 按选项评估内容
+func (e *RuleEngine) EvaluateWith(ctx *model.CheckContext, existingRisks []*model.RiskItem, opts RuleEvalOptions) (*RuleEngineResult, error) {
+	ruleSet, trace := opts.RuleSet, opts.Trace
+	if ruleSet == nil {
+		e.mu.RLock()
+		ruleSet = e.ruleSet
+		initialized := e.initialized
+		e.mu.RUnlock()
+
+		if !initialized {
+			return nil, fmt.Errorf("rule engine not initialized")
+		}
 	}
 
 	result := &RuleEngineResult{
-		Result: model.ResultTypePass,
-		Risks:  make([]*model.RiskItem, 0),
+		Result:      model.ResultTypePass,
+		Risks:       make([]*model.RiskItem, 0),
+		RuleVersion: ruleSet.Version,
 	}
 
-	// 按优先级排序规则（高优先级先处理）
-	sortedRules := e.sortRulesByPriority()
+	// 规则已按优先级排序（高优先级先处理）
+	sortedRules := ruleSet.sorted
 
-	// 评估每条规则
+	// 评估每条规则，记录动作最严重的命中规则，同等严重时取分数最高者
 	var highestScore float32
 	var highestScoreResult model.ResultType
+	var highestScoreRule string
+	matchedAction := false
 
-	// 创建已存在风险类型的映射，用于快速查找
-	existingRiskTypes := make(map[model.RiskType]bool)
-	for _, risk := range existingRisks {
-		existingRiskTypes[risk.Type] = true
-	}
+	// 条件基于检测器的结果求值
+	env := newConditionEnv(ctx, existingRisks)
 
-	for _, rule := range sortedRules {
+	for i, rule := range sortedRules {
+		rule = applyRuleOverride(rule, opts.Overrides)
 		if !rule.Enabled {
+			trace.AddRule(newRuleTrace(rule, false, "disabled"))
 			continue
 		}
 
 		// 评估规则
-		matched, riskItem := e.evaluateRule(rule, ctx, existingRiskTypes)
+		matched, riskItem := e.evaluateRule(rule, env)
+		if trace != nil {
+			ruleTrace := newRuleTrace(rule, true, "")
+			ruleTrace.Matched, ruleTrace.Reason = rule.Condition.Explain(env)
+			trace.AddRule(ruleTrace)
+		}
 		if matched {
 			// 添加新的风险项
 			if riskItem != nil {
 				result.Risks = append(result.Risks, riskItem)
 			}
 
-			// 如果规则要求立即拒绝，提前返回结果
+			// 无操作的规则只记录风险项，不影响审核结果
 			actionType := e.getActionType(rule.Action)
+			if actionType == model.ResultTypePass {
+				continue
+			}
 			score := rule.Score
 
-			if score > highestScore {
+			if !matchedAction || actionType.Severity() > highestScoreResult.Severity() ||
+				(actionType == highestScoreResult && score > highestScore) {
+				matchedAction = true
 				highestScore = score
 				highestScoreResult = actionType
+				highestScoreRule = rule.ID
 			}
 
 			// 如果是阻止操作，提前结束
@@ -159,134 +456,123 @@ func (e *RuleEngine) Evaluate(ctx *model.CheckContext, existingRisks []*model.Ri
 				result.Result = model.ResultTypeReject
 				result.Score = score
 				result.HasExplicitResult = true
+				result.RuleID = rule.ID
 				result.Suggestion = e.generateSuggestion(rule)
+				for _, skipped := range sortedRules[i+1:] {
+					skipped = applyRuleOverride(skipped, opts.Overrides)
+					trace.AddRule(newRuleTrace(skipped, false, "skipped: evaluation stopped by rule "+rule.ID))
+				}
 				return result, nil
 			}
 		}
 	}
 
-	// 设置最高分数和对应结果
-	result.Score = highestScore
-	if highestScore > 0 {
+	// 审核、标记规则只给出结果下限，由调用方与阈值判定的结果取较严重者
+	if matchedAction {
 		result.Result = highestScoreResult
-		result.HasExplicitResult = true
+		result.Score = highestScore
+		result.RuleID = highestScoreRule
 	}
 
 	return result, nil
 }
 
+// ParseCandidateRuleSet 解析并校验候选规则集，用于试运行，不影响当前规则集
+func ParseCandidateRuleSet(data []byte) (*RuleSet, error) {
+	ruleSet, err := parseRuleSet(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid candidate rule set: %v", ErrInvalidRequest, err)
+	}
+	ruleSet.Checksum = ruleSetChecksum(data)
+	ruleSet.Version = "candidate-" + ruleSet.Checksum[:8]
+	ruleSet.LoadedAt = time.Now()
+	return ruleSet, nil
+}
+
+// applyRuleOverride 返回应用场景覆盖后的规则，无覆盖时返回原规则
+func applyRuleOverride(rule *Rule, overrides map[string]RuleOverride) *Rule {
+	override, ok := overrides[rule.ID]
+	if !ok {
+		return rule
+	}
+
+	overridden := *rule
+	if override.Enabled != nil {
+		overridden.Enabled = *override.Enabled
+	}
+	if override.Action != "" {
+		overridden.Action = override.Action
+	}
+	if override.Score != nil {
+		overridden.Score = *override.Score
+	}
+	return &overridden
+}
+
+// newRuleTrace 创建规则求值记录
+func newRuleTrace(rule *Rule, evaluated bool, reason string) *model.RuleTrace {
+	return &model.RuleTrace{
+		ID:        rule.ID,
+		Name:      rule.Name,
+		Priority:  rule.Priority,
+		Action:    rule.Action,
+		Score:     rule.Score,
+		Enabled:   rule.Enabled,
+		Evaluated: evaluated,
+		Reason:    reason,
+	}
+}
+
 // getActionType 获取动作类型
 func (e *RuleEngine) getActionType(action string) model.ResultType {
 	switch action {
-	case "block":
+	case RuleActionBlock:
 		return model.ResultTypeReject
-	case "review":
+	case RuleActionReview:
 		return model.ResultTypeReview
-	case "mark":
+	case RuleActionMark:
 		return model.ResultTypeWarning
 	default:
 		return model.ResultTypePass
 	}
 }
 
-// evaluateRule 评估单条规则
-func (e *RuleEngine) evaluateRule(rule *Rule, ctx *model.CheckContext, existingRiskTypes map[model.RiskType]bool) (bool, *model.RiskItem) {
-	var riskType model.RiskType
+// compileRule 校验规则并编译命中条件
+func compileRule(rule *Rule) error {
+	if rule.Condition == nil {
+		return fmt.Errorf("condition is required")
+	}
+	if err := rule.Condition.Compile(); err != nil {
+		return err
+	}
 
-	// 根据规则类型处理
-	switch rule.ID {
-	case "sensitive_words":
-		// 敏感词检测已经由detector完成，这里只需处理未被检测到的情况
-		categoryStr, ok := rule.Config["category"].(string)
+	rule.riskType = model.RiskTypeUnknown
+	if rule.RiskType != "" {
+		riskType, ok := model.ParseRiskType(rule.RiskType)
 		if !ok {
-			return false, nil
-		}
-
-		riskType = e.getRiskTypeFromCategory(categoryStr)
-		if existingRiskTypes[riskType] {
-			return false, nil // 已经被检测到了
-		}
-
-		// 这里可以添加额外的检测逻辑
-
-	case "spam_detection":
-		// 垃圾信息检测
-		riskType = model.RiskTypeSpam
-		if existingRiskTypes[riskType] {
-			return false, nil
-		}
-
-		// 这里可以添加额外的检测逻辑
-
-	case "context_analysis":
-		// 上下文分析
-		if len(ctx.ContextItems) == 0 {
-			return false, nil // 没有上下文
-		}
-
-		riskType = model.RiskTypeContextViolation
-		if existingRiskTypes[riskType] {
-			return false, nil
+			return fmt.Errorf("unknown risk_type %q", rule.RiskType)
 		}
+		rule.riskType = riskType
+	}
 
-		// 根据上下文进行额外检测
-		// 这里可以实现更复杂的上下文分析逻辑
-
-	case "user_reputation":
-		// 用户信誉度分析
-		riskType = model.RiskTypeSuspiciousBehavior
-		if existingRiskTypes[riskType] {
-			return false, nil
-		}
+	return nil
+}
 
-		// 这里可以添加用户信誉度分析逻辑
+// evaluateRule 评估单条规则，命中时返回规则生成的风险项
+func (e *RuleEngine) evaluateRule(rule *Rule, env *conditionEnv) (bool, *model.RiskItem) {
+	if rule.Condition == nil || !rule.Condition.Evaluate(env) {
+		return false, nil
 	}
 
-	// 在实际项目中，这里应该有更复杂的规则匹配逻辑
-	// 简化版本中，我们直接返回未匹配
-	return false, nil
+	riskItem := model.NewRiskItem(rule.riskType, rule.Score, fmt.Sprintf("命中规则: %s", rule.Name))
+	riskItem.Details["rule_id"] = rule.ID
+	riskItem.Details["rule_name"] = rule.Name
+	riskItem.Details["action"] = rule.Action
+
+	return true, riskItem
 }
 
 // 生成建议信息
 func (e *RuleEngine) generateSuggestion(rule *Rule) string {
 	return fmt.Sprintf("内容违反了\"%s\"规则，原因：%s", rule.Name, rule.Description)
 }
-
-// 获取风险类型
-func (e *RuleEngine) getRiskTypeFromCategory(category string) model.RiskType {
-	switch category {
-	case "sensitive":
-		return model.RiskTypeSensitiveWord
-	case "spam":
-		return model.RiskTypeSpam
-	case "harassment":
-		return model.RiskTypeHarassment
-	case "hate_speech":
-		return model.RiskTypeHateSpeech
-	case "violence":
-		return model.RiskTypeViolence
-	case "adult":
-		return model.RiskTypeAdult
-	default:
-		return model.RiskTypeUnknown
-	}
-}
-
-// 按优先级排序规则
-func (e *RuleEngine) sortRulesByPriority() []*Rule {
-	rules := make([]*Rule, 0, len(e.ruleSet.Rules))
-	for _, rule := range e.ruleSet.Rules {
-		rules = append(rules, rule)
-	}
-
-	// 按优先级排序（降序）
-	for i := 0; i < len(rules)-1; i++ {
-		for j := i + 1; j < len(rules); j++ {
-			if rules[i].Priority < rules[j].Priority {
-				rules[i], rules[j] = rules[j], rules[i]
-			}
-		}
-	}
-
-	return rules
-}
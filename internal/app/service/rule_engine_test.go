@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// testRuleFile 返回只包含一条规则的规则文件内容
+func testRuleFile(id string) []byte {
+	return []byte(fmt.Sprintf(`{"rules":[{"id":%q,"enabled":true,"action":"mark","score":30,"condition":{"field":"content","op":"contains","value":"x"}}]}`, id))
+}
+
+// waitFor 轮询直到cond成立，超时后报告失败
+func waitFor(t *testing.T, timeout time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRuleEngineWatchReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, testRuleFile("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := NewRuleEngine(path, "", zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := engine.WatchStatus().State; got != RuleWatchDisabled {
+		t.Fatalf("state before Start = %s, want %s", got, RuleWatchDisabled)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go engine.Start(ctx, 0, true)
+	waitFor(t, time.Second, "watcher to start", func() bool {
+		return engine.WatchStatus().State == RuleWatchWatching
+	})
+	// 等待目录监听建立
+	time.Sleep(50 * time.Millisecond)
+
+	first := engine.Version()
+	if err := writeFileAtomic(path, testRuleFile("second")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 3*time.Second, "rules to reload", func() bool {
+		_, err := engine.GetRule("second")
+		return err == nil
+	})
+	if engine.Version() == first {
+		t.Errorf("version unchanged after reload: %s", first)
+	}
+
+	// 无效的规则文件被拒绝，保留当前规则
+	if err := writeFileAtomic(path, []byte(`{"rules":[{"id":""}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(fileWatchDebounce + 200*time.Millisecond)
+	if _, err := engine.GetRule("second"); err != nil {
+		t.Errorf("invalid rule file replaced the current rules: %v", err)
+	}
+}
+
+func TestRuleEngineWatchRetries(t *testing.T) {
+	oldMin, oldMax := ruleWatchRetryMin, ruleWatchRetryMax
+	ruleWatchRetryMin, ruleWatchRetryMax = 5*time.Millisecond, 20*time.Millisecond
+	defer func() { ruleWatchRetryMin, ruleWatchRetryMax = oldMin, oldMax }()
+
+	dir := filepath.Join(t.TempDir(), "rules")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "rules.json")
+	if err := os.WriteFile(path, testRuleFile("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := NewRuleEngine(path, "", zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 规则目录不存在时无法监听，按退避间隔重试并在健康状态中体现
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		engine.Start(ctx, 0, true)
+		close(done)
+	}()
+	waitFor(t, time.Second, "repeated watch failures", func() bool {
+		status := engine.WatchStatus()
+		return status.State == RuleWatchRetrying && status.Failures >= 2 && status.LastError != ""
+	})
+
+	// 目录恢复后重新监听，并重新加载中断期间的变更
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, testRuleFile("second"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, time.Second, "watch to recover", func() bool {
+		_, err := engine.GetRule("second")
+		return err == nil && engine.WatchStatus().State == RuleWatchWatching
+	})
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after cancel")
+	}
+	if got := engine.WatchStatus().State; got != RuleWatchDisabled {
+		t.Errorf("state after cancel = %s, want %s", got, RuleWatchDisabled)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ruleFileAuthor 从规则文件加载的版本作者
+const ruleFileAuthor = "file"
+
+// RuleAuthor 规则变更的操作者
+type RuleAuthor struct {
+	Identity string // 通过认证的操作者身份，记录为版本作者
+	Claimed  string // 请求中声明的作者，未经认证，仅供参考
+}
+
+// RuleSetVersion 不可变的规则集版本
+type RuleSetVersion struct {
+	Version       string          `json:"version"`
+	Author        string          `json:"author"`                   // 通过认证的操作者身份
+	ClaimedAuthor string          `json:"claimed_author,omitempty"` // 请求中声明的作者，未经认证
+	Comment       string          `json:"comment"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Checksum      string          `json:"checksum"`
+	RuleSet       json.RawMessage `json:"rule_set,omitempty"`
+}
+
+// ruleHistory 规则集版本历史，dir不为空时每个版本保存为dir下的一个JSON文件
+type ruleHistory struct {
+	dir      string
+	mu       sync.RWMutex
+	versions []*RuleSetVersion // 按创建时间升序
+}
+
+// newRuleHistory 创建版本历史并加载dir中已有的版本
+func newRuleHistory(dir string) (*ruleHistory, error) {
+	h := &ruleHistory{dir: dir}
+	if dir == "" {
+		return h, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create rule history dir: %w", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule history: %w", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule version %s: %w", file, err)
+		}
+		var version RuleSetVersion
+		if err := json.Unmarshal(data, &version); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule version %s: %w", file, err)
+		}
+		h.versions = append(h.versions, &version)
+	}
+
+	sort.SliceStable(h.versions, func(i, j int) bool {
+		return h.versions[i].CreatedAt.Before(h.versions[j].CreatedAt)
+	})
+
+	return h, nil
+}
+
+// record 记录新版本并持久化
+func (h *ruleHistory) record(data []byte, author RuleAuthor, comment string) (*RuleSetVersion, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	checksum := ruleSetChecksum(data)
+	now := time.Now()
+	version := &RuleSetVersion{
+		Version:       fmt.Sprintf("%s-%s", now.Format("20060102150405"), checksum[:8]),
+		Author:        author.Identity,
+		ClaimedAuthor: author.Claimed,
+		Comment:       comment,
+		CreatedAt:     now,
+		Checksum:      checksum,
+		RuleSet:       json.RawMessage(data),
+	}
+
+	// 同一秒内内容相同的版本号会重复，追加序号区分
+	for i := 2; h.find(version.Version) != nil; i++ {
+		version.Version = fmt.Sprintf("%s-%s-%d", now.Format("20060102150405"), checksum[:8], i)
+	}
+
+	if h.dir != "" {
+		encoded, err := json.MarshalIndent(version, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal rule version: %w", err)
+		}
+		if err := writeFileAtomic(filepath.Join(h.dir, version.Version+".json"), encoded); err != nil {
+			return nil, err
+		}
+	}
+
+	h.versions = append(h.versions, version)
+	return version, nil
+}
+
+// latest 返回最近的版本
+func (h *ruleHistory) latest() *RuleSetVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.versions) == 0 {
+		return nil
+	}
+	return h.versions[len(h.versions)-1]
+}
+
+// get 按版本号查找版本
+func (h *ruleHistory) get(version string) *RuleSetVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.find(version)
+}
+
+// find 按版本号查找版本，调用方需持有锁
+func (h *ruleHistory) find(version string) *RuleSetVersion {
+	for _, v := range h.versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+// list 返回所有版本的元信息（不含规则内容），按创建时间降序
+func (h *ruleHistory) list() []*RuleSetVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]*RuleSetVersion, 0, len(h.versions))
+	for i := len(h.versions) - 1; i >= 0; i-- {
+		meta := *h.versions[i]
+		meta.RuleSet = nil
+		result = append(result, &meta)
+	}
+	return result
+}
+
+// ruleSetChecksum 计算规则文件内容的校验和
+func ruleSetChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
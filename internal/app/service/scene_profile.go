@@ -0,0 +1,238 @@
+package service
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// defaultSceneProfile 兜底场景策略名
+const defaultSceneProfile = "default"
+
+// maxUnknownSceneLabels 未知场景指标中单独计数的场景数上限，超过后计入 _other，避免场景名任意取值导致指标膨胀
+const maxUnknownSceneLabels = 100
+
+// unknownScenes 未知场景计数，通过 /debug/vars 暴露
+var unknownScenes = expvar.NewMap("unknown_scenes")
+
+// SceneProfile 场景策略
+type SceneProfile struct {
+	Name            string
+	Detectors       map[string]bool // 启用的检测器，为nil时启用全部检测器
+	Weights         map[model.RiskType]float32
+	DetectorWeights map[string]float32
+	Aggregator      ScoreAggregator
+	Thresholds      config.ThresholdsConfig
+	RuleOverrides   map[string]RuleOverride
+}
+
+// RuleOverride 场景内对规则的覆盖
+type RuleOverride struct {
+	Enabled *bool
+	Action  string
+	Score   *float32
+}
+
+// sceneProfiles 场景策略集合
+type sceneProfiles struct {
+	profiles map[string]*SceneProfile
+	fallback *SceneProfile
+
+	mu             sync.Mutex
+	unknownCounted map[string]bool
+}
+
+// newSceneProfiles 解析场景策略配置，default 未配置阈值时按 riskScoreThreshold 计算，
+// 其余场景未配置的项沿用 default
+func newSceneProfiles(cfg map[string]config.SceneProfileConfig, riskScoreThreshold float32) (*sceneProfiles, error) {
+	fallback, err := newSceneProfile(defaultSceneProfile, cfg[defaultSceneProfile], nil)
+	if err != nil {
+		return nil, err
+	}
+	if fallback.Thresholds == (config.ThresholdsConfig{}) {
+		fallback.Thresholds = defaultThresholds(riskScoreThreshold)
+	}
+	if fallback.Aggregator == nil {
+		fallback.Aggregator, _ = NewScoreAggregator(AggregatorMax)
+	}
+
+	profiles := &sceneProfiles{
+		profiles:       map[string]*SceneProfile{defaultSceneProfile: fallback},
+		fallback:       fallback,
+		unknownCounted: make(map[string]bool),
+	}
+	for name, profileCfg := range cfg {
+		if name == defaultSceneProfile {
+			continue
+		}
+		profile, err := newSceneProfile(name, profileCfg, fallback)
+		if err != nil {
+			return nil, err
+		}
+		profiles.profiles[name] = profile
+	}
+
+	return profiles, nil
+}
+
+// defaultThresholds 以 riskScoreThreshold 为拒绝阈值，人工审核及警告阈值分别为其0.7及0.5倍
+func defaultThresholds(riskScoreThreshold float32) config.ThresholdsConfig {
+	return config.ThresholdsConfig{
+		Reject:  riskScoreThreshold,
+		Review:  riskScoreThreshold * 0.7,
+		Warning: riskScoreThreshold * 0.5,
+	}
+}
+
+// newSceneProfile 解析单个场景策略，base不为nil时未配置的项沿用base
+func newSceneProfile(name string, cfg config.SceneProfileConfig, base *SceneProfile) (*SceneProfile, error) {
+	profile := &SceneProfile{
+		Name:       name,
+		Thresholds: cfg.Thresholds,
+	}
+
+	if len(cfg.Detectors) > 0 {
+		profile.Detectors = make(map[string]bool, len(cfg.Detectors))
+		for _, detector := range cfg.Detectors {
+			profile.Detectors[detector] = true
+		}
+	}
+
+	if len(cfg.Weights) > 0 {
+		profile.Weights = make(map[model.RiskType]float32, len(cfg.Weights))
+		for riskName, weight := range cfg.Weights {
+			riskType, ok := model.ParseRiskType(riskName)
+			if !ok {
+				return nil, fmt.Errorf("scene %s: unknown risk type %q in weights", name, riskName)
+			}
+			if weight < 0 {
+				return nil, fmt.Errorf("scene %s: weight of %s must not be negative", name, riskName)
+			}
+			profile.Weights[riskType] = weight
+		}
+	}
+
+	if len(cfg.DetectorWeights) > 0 {
+		profile.DetectorWeights = make(map[string]float32, len(cfg.DetectorWeights))
+		for detector, weight := range cfg.DetectorWeights {
+			if weight < 0 {
+				return nil, fmt.Errorf("scene %s: weight of detector %s must not be negative", name, detector)
+			}
+			profile.DetectorWeights[detector] = weight
+		}
+	}
+
+	if cfg.Aggregator != "" {
+		aggregator, err := NewScoreAggregator(cfg.Aggregator)
+		if err != nil {
+			return nil, fmt.Errorf("scene %s: %w", name, err)
+		}
+		profile.Aggregator = aggregator
+	}
+
+	if len(cfg.RuleOverrides) > 0 {
+		profile.RuleOverrides = make(map[string]RuleOverride, len(cfg.RuleOverrides))
+		for ruleID, override := range cfg.RuleOverrides {
+			switch override.Action {
+			case "", RuleActionBlock, RuleActionReview, RuleActionMark, RuleActionNone:
+			default:
+				return nil, fmt.Errorf("scene %s: rule %s: unknown action %q", name, ruleID, override.Action)
+			}
+			profile.RuleOverrides[ruleID] = RuleOverride{
+				Enabled: override.Enabled,
+				Action:  override.Action,
+				Score:   override.Score,
+			}
+		}
+	}
+
+	if t := profile.Thresholds; t != (config.ThresholdsConfig{}) {
+		if t.Reject <= 0 || t.Review > t.Reject || t.Warning > t.Reject || (t.Review > 0 && t.Warning > t.Review) {
+			return nil, fmt.Errorf("scene %s: thresholds must satisfy reject > 0 and reject >= review >= warning", name)
+		}
+	}
+
+	if base != nil {
+		if profile.Detectors == nil {
+			profile.Detectors = base.Detectors
+		}
+		if profile.Weights == nil {
+			profile.Weights = base.Weights
+		}
+		if profile.DetectorWeights == nil {
+			profile.DetectorWeights = base.DetectorWeights
+		}
+		if profile.Aggregator == nil {
+			profile.Aggregator = base.Aggregator
+		}
+		if profile.Thresholds == (config.ThresholdsConfig{}) {
+			profile.Thresholds = base.Thresholds
+		}
+		if profile.RuleOverrides == nil {
+			profile.RuleOverrides = base.RuleOverrides
+		}
+	}
+
+	return profile, nil
+}
+
+// resolve 返回场景对应的策略，未知场景使用 default 并计入指标
+func (p *sceneProfiles) resolve(scene string) *SceneProfile {
+	if profile, ok := p.profiles[scene]; ok {
+		return profile
+	}
+	if scene != "" {
+		p.countUnknown(scene)
+	}
+	return p.fallback
+}
+
+// countUnknown 记录未知场景
+func (p *sceneProfiles) countUnknown(scene string) {
+	p.mu.Lock()
+	if !p.unknownCounted[scene] {
+		if len(p.unknownCounted) >= maxUnknownSceneLabels {
+			scene = "_other"
+		} else {
+			p.unknownCounted[scene] = true
+		}
+	}
+	p.mu.Unlock()
+
+	unknownScenes.Add(scene, 1)
+}
+
+// RunsDetector 判断场景是否启用检测器
+func (p *SceneProfile) RunsDetector(name string) bool {
+	return p.Detectors == nil || p.Detectors[name]
+}
+
+// Weight 返回检测器输出的风险类型的分数权重，为检测器权重与风险类型权重之积
+func (p *SceneProfile) Weight(detector string, riskType model.RiskType) float32 {
+	weight := float32(1)
+	if w, ok := p.DetectorWeights[detector]; ok {
+		weight *= w
+	}
+	if w, ok := p.Weights[riskType]; ok {
+		weight *= w
+	}
+	return weight
+}
+
+// Decide 按阈值判定结果，返回结果及命中的阈值名称和阈值，review或warning为0时不启用该档
+func (p *SceneProfile) Decide(score float32) (model.ResultType, string, float32) {
+	t := p.Thresholds
+	switch {
+	case score >= t.Reject:
+		return model.ResultTypeReject, "reject", t.Reject
+	case t.Review > 0 && score >= t.Review:
+		return model.ResultTypeReview, "review", t.Review
+	case t.Warning > 0 && score >= t.Warning:
+		return model.ResultTypeWarning, "warning", t.Warning
+	default:
+		return model.ResultTypePass, "pass", 0
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+func TestSceneProfilesDefaultThresholds(t *testing.T) {
+	profiles, err := newSceneProfiles(map[string]config.SceneProfileConfig{
+		"chat":    {},
+		"comment": {Thresholds: config.ThresholdsConfig{Reject: 60}},
+	}, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config.ThresholdsConfig{Reject: 70, Review: 49, Warning: 35}
+	if got := profiles.fallback.Thresholds; got != want {
+		t.Errorf("default thresholds = %+v, want %+v", got, want)
+	}
+	if got := profiles.profiles["chat"].Thresholds; got != want {
+		t.Errorf("chat thresholds = %+v, want inherited %+v", got, want)
+	}
+	// 显式配置的阈值不补全，review/warning 为0表示不启用该档
+	if got := profiles.profiles["comment"].Thresholds; got != (config.ThresholdsConfig{Reject: 60}) {
+		t.Errorf("comment thresholds = %+v, want reject only", got)
+	}
+
+	tests := []struct {
+		score float32
+		want  model.ResultType
+	}{
+		{80, model.ResultTypeReject},
+		{50, model.ResultTypeReview},
+		{40, model.ResultTypeWarning},
+		{20, model.ResultTypePass},
+	}
+	for _, tt := range tests {
+		if got, _, _ := profiles.fallback.Decide(tt.score); got != tt.want {
+			t.Errorf("Decide(%v) = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// 分数聚合策略
+const (
+	AggregatorMax              = "max"                // 取最高分
+	AggregatorWeightedSum      = "weighted_sum"       // 加权分数求和
+	AggregatorNoisyOr          = "noisy_or"           // 将分数视为独立风险概率，合并为至少一项成立的概率
+	AggregatorRiskTypeWeighted = "risk_type_weighted" // 同类风险取最高分后跨类型求和，避免多个检测器重复计分
+)
+
+// maxRiskScore 风险分数上限
+const maxRiskScore float32 = 100
+
+// ScoreInput 参与聚合的风险分数
+type ScoreInput struct {
+	Source   string // 检测器名称或规则ID
+	RiskType model.RiskType
+	Score    float32 // 原始分数
+	Weight   float32 // 检测器权重与风险类型权重之积
+}
+
+// weighted 返回加权后的分数
+func (in ScoreInput) weighted() float32 {
+	return clampScore(in.Score * in.Weight)
+}
+
+// ScoreAggregator 分数聚合策略，将各风险项的加权分数合并为最终分数（0-100）
+type ScoreAggregator interface {
+	Name() string
+	Aggregate(inputs []ScoreInput) float32
+}
+
+// NewScoreAggregator 按名称创建分数聚合策略，名称为空时使用max
+func NewScoreAggregator(name string) (ScoreAggregator, error) {
+	switch name {
+	case "", AggregatorMax:
+		return maxAggregator{}, nil
+	case AggregatorWeightedSum:
+		return weightedSumAggregator{}, nil
+	case AggregatorNoisyOr:
+		return noisyOrAggregator{}, nil
+	case AggregatorRiskTypeWeighted:
+		return riskTypeWeightedAggregator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown score aggregator %q", name)
+	}
+}
+
+// maxAggregator 取最高分
+type maxAggregator struct{}
+
+func (maxAggregator) Name() string { return AggregatorMax }
+
+func (maxAggregator) Aggregate(inputs []ScoreInput) float32 {
+	var score float32
+	for _, in := range inputs {
+		if s := in.weighted(); s > score {
+			score = s
+		}
+	}
+	return score
+}
+
+// weightedSumAggregator 加权分数求和，结果不超过100
+type weightedSumAggregator struct{}
+
+func (weightedSumAggregator) Name() string { return AggregatorWeightedSum }
+
+func (weightedSumAggregator) Aggregate(inputs []ScoreInput) float32 {
+	var score float32
+	for _, in := range inputs {
+		score += in.weighted()
+	}
+	return clampScore(score)
+}
+
+// noisyOrAggregator 1 - ∏(1 - p)，p为加权分数/100
+type noisyOrAggregator struct{}
+
+func (noisyOrAggregator) Name() string { return AggregatorNoisyOr }
+
+func (noisyOrAggregator) Aggregate(inputs []ScoreInput) float32 {
+	pass := float32(1)
+	for _, in := range inputs {
+		pass *= 1 - in.weighted()/maxRiskScore
+	}
+	return clampScore((1 - pass) * maxRiskScore)
+}
+
+// riskTypeWeightedAggregator 每种风险类型取最高加权分，再跨类型求和，结果不超过100
+type riskTypeWeightedAggregator struct{}
+
+func (riskTypeWeightedAggregator) Name() string { return AggregatorRiskTypeWeighted }
+
+func (riskTypeWeightedAggregator) Aggregate(inputs []ScoreInput) float32 {
+	byType := make(map[model.RiskType]float32)
+	for _, in := range inputs {
+		if s := in.weighted(); s > byType[in.RiskType] {
+			byType[in.RiskType] = s
+		}
+	}
+
+	var score float32
+	for _, s := range byType {
+		score += s
+	}
+	return clampScore(score)
+}
+
+// clampScore 将分数限制在[0, 100]
+func clampScore(score float32) float32 {
+	if score < 0 {
+		return 0
+	}
+	if score > maxRiskScore {
+		return maxRiskScore
+	}
+	return score
+}
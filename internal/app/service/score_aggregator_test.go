@@ -0,0 +1,68 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+func TestScoreAggregators(t *testing.T) {
+	inputs := []ScoreInput{
+		{Source: "keyword", RiskType: model.RiskTypeHarassment, Score: 60, Weight: 1},
+		{Source: "nlp", RiskType: model.RiskTypeHarassment, Score: 80, Weight: 0.5},
+		{Source: "spam", RiskType: model.RiskTypeSpam, Score: 50, Weight: 1},
+	}
+	overflow := []ScoreInput{
+		{Source: "a", RiskType: model.RiskTypeHarassment, Score: 90, Weight: 1},
+		{Source: "b", RiskType: model.RiskTypeSpam, Score: 80, Weight: 1},
+	}
+	heavy := []ScoreInput{{Source: "a", RiskType: model.RiskTypeHarassment, Score: 80, Weight: 2}}
+
+	tests := []struct {
+		name   string
+		inputs []ScoreInput
+		want   float32
+	}{
+		{AggregatorMax, nil, 0},
+		{AggregatorMax, inputs, 60},
+		{AggregatorMax, heavy, 100},
+		{AggregatorWeightedSum, inputs, 100},
+		{AggregatorWeightedSum, inputs[1:], 90},
+		{AggregatorWeightedSum, overflow, 100},
+		{AggregatorNoisyOr, nil, 0},
+		// 1 - 0.4 * 0.6 * 0.5
+		{AggregatorNoisyOr, inputs, 88},
+		{AggregatorNoisyOr, overflow, 98},
+		{AggregatorNoisyOr, heavy, 100},
+		// 同类风险取最高分 max(60, 40)，跨类型求和
+		{AggregatorRiskTypeWeighted, inputs[:2], 60},
+		{AggregatorRiskTypeWeighted, []ScoreInput{inputs[0], inputs[2]}, 100},
+		{AggregatorRiskTypeWeighted, []ScoreInput{inputs[1], inputs[2]}, 90},
+	}
+	for _, tt := range tests {
+		agg, err := NewScoreAggregator(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if agg.Name() != tt.name {
+			t.Errorf("NewScoreAggregator(%q).Name() = %q", tt.name, agg.Name())
+		}
+		if got := agg.Aggregate(tt.inputs); math.Abs(float64(got-tt.want)) > 0.01 {
+			t.Errorf("%s.Aggregate(%+v) = %v, want %v", tt.name, tt.inputs, got, tt.want)
+		}
+	}
+}
+
+func TestNewScoreAggregatorDefault(t *testing.T) {
+	agg, err := NewScoreAggregator("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.Name() != AggregatorMax {
+		t.Errorf("default aggregator = %q, want %q", agg.Name(), AggregatorMax)
+	}
+	if _, err := NewScoreAggregator("average"); err == nil {
+		t.Error("NewScoreAggregator(average) succeeded, want error")
+	}
+}
@@ -2,35 +2,101 @@ package service
 
 import (
 	"bufio"
+	"context"
+	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
 
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/ahocorasick"
 	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+	"github.com/aa12gq/content-risk-control/internal/pkg/pinyin"
 )
 
+// defaultWordCategory 未归属任何分组的敏感词类别
+const defaultWordCategory = "default"
+
 // SensitiveWords 敏感词检测器，实现detector.SensitiveWordChecker接口
 type SensitiveWords struct {
-	words     map[string]bool
-	logger    *zap.SugaredLogger
-	mu        sync.RWMutex
-	filePaths []string
+	words          map[string]*dictEntry                        // 敏感词 -> 词条
+	categories     map[string]*SensitiveWordCategory            // 类别配置
+	sourceCats     map[string]map[string]*SensitiveWordCategory // 来源 -> 来源中定义的类别，写回时使用
+	homophones     map[string][]string                          // 敏感词 -> 谐音变体
+	allowlist      *Allowlist                                   // 豁免短语
+	matcher        *wordMatcher
+	allowMatcher   *allowMatcher
+	normalizer     *normalizer.Normalizer // 词条归一化，需与检测内容使用同一流水线
+	pinyinEnabled  bool                   // 是否启用拼音/首字母/同音字匹配
+	logger         *zap.SugaredLogger
+	mu             sync.RWMutex
+	storeMu        sync.Mutex // 串行化词库的加载与写回
+	sources        []WordSource
+	snapshots      map[string]*SensitiveWordDict // 来源 -> 最近一次成功加载的词库，来源加载失败时使用
+	version        WordListVersion
+	maxShrink      int // 单次重载允许减少的词条比例上限（百分比）
+	homophonePaths []string
+	allowlistPaths []string
+}
+
+// wordEntry 匹配器中的一个模式
+type wordEntry struct {
+	entry     *dictEntry
+	matchType string
+}
+
+// wordMatcher 由当前词库编译得到的只读匹配器
+type wordMatcher struct {
+	automaton *ahocorasick.Automaton // 原词及谐音变体
+	entries   []wordEntry
+
+	pinyinAutomaton *ahocorasick.Automaton // 全拼
+	pinyinEntries   []wordEntry
+	initials        map[string][]wordEntry // 拼音首字母 -> 词条
 }
 
 // 确保SensitiveWords实现了detector.SensitiveWordChecker接口
 var _ detector.SensitiveWordChecker = (*SensitiveWords)(nil)
 
-// NewSensitiveWords 创建敏感词检测器
+// NewSensitiveWords 创建敏感词检测器，从本地结构化词库文件加载
 func NewSensitiveWords(logger *zap.SugaredLogger) *SensitiveWords {
+	return NewSensitiveWordsWithSources(logger, []WordSource{
+		NewFileWordSource("config/sensitive_words.json", false),
+	}, defaultMaxShrinkPercent)
+}
+
+// NewSensitiveWordsWithSources 创建从指定来源加载的敏感词检测器，
+// 多个来源的词条合并，同一个词以后面的来源为准
+func NewSensitiveWordsWithSources(logger *zap.SugaredLogger, sources []WordSource, maxShrinkPercent int) *SensitiveWords {
+	if maxShrinkPercent <= 0 {
+		maxShrinkPercent = defaultMaxShrinkPercent
+	}
+
 	sw := &SensitiveWords{
-		words:  make(map[string]bool),
-		logger: logger,
-		filePaths: []string{
-			"config/sensitive_words.txt",
+		words:      make(map[string]*dictEntry),
+		categories: make(map[string]*SensitiveWordCategory),
+		sourceCats: make(map[string]map[string]*SensitiveWordCategory),
+		snapshots:  make(map[string]*SensitiveWordDict),
+		homophones: make(map[string][]string),
+		logger:     logger,
+		sources:    sources,
+		maxShrink:  maxShrinkPercent,
+		homophonePaths: []string{
+			"config/homophones.txt",
+		},
+		allowlistPaths: []string{
+			"config/allowlist.json",
 		},
 	}
+	sw.matcher = sw.buildMatcher(sw.words, sw.homophones)
+	sw.allowMatcher = buildAllowMatcher(nil, nil)
 
 	// 加载敏感词
 	if err := sw.Update(); err != nil {
@@ -40,31 +106,273 @@ func NewSensitiveWords(logger *zap.SugaredLogger) *SensitiveWords {
 	return sw
 }
 
-// Update 更新敏感词库
+// buildMatcher 使用当前的归一化及拼音配置编译匹配器，调用方需持有锁
+func (sw *SensitiveWords) buildMatcher(words map[string]*dictEntry, homophones map[string][]string) *wordMatcher {
+	return buildWordMatcher(words, homophones, sw.normalizer, sw.pinyinEnabled)
+}
+
+// buildWordMatcher 根据词库编译匹配器，norm不为空时词条先归一化再编译
+func buildWordMatcher(words map[string]*dictEntry, homophones map[string][]string, norm *normalizer.Normalizer, withPinyin bool) *wordMatcher {
+	sorted := make([]string, 0, len(words))
+	for word := range words {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+
+	normalize := func(s string) string {
+		if norm == nil {
+			return s
+		}
+		if n := norm.String(s); n != "" {
+			return n
+		}
+		return s
+	}
+
+	m := &wordMatcher{
+		initials: make(map[string][]wordEntry),
+	}
+
+	// 1. 原词
+	var patterns []string
+	for _, word := range sorted {
+		m.entries = append(m.entries, wordEntry{entry: words[word], matchType: detector.MatchTypeExact})
+		patterns = append(patterns, normalize(word))
+	}
+
+	// 2. 配置的谐音变体，归属于对应原词
+	for _, word := range sorted {
+		for _, variant := range homophones[word] {
+			m.entries = append(m.entries, wordEntry{entry: words[word], matchType: detector.MatchTypeHomophone})
+			patterns = append(patterns, normalize(variant))
+		}
+	}
+	m.automaton = ahocorasick.Build(patterns)
+
+	// 3. 全拼及首字母，仅索引至少两个汉字的词条
+	var pinyinPatterns []string
+	if withPinyin {
+		for _, word := range sorted {
+			normalized := normalize(word)
+			if utf8.RuneCountInString(normalized) < 2 {
+				continue
+			}
+
+			full, ok := pinyin.Full(normalized)
+			if !ok {
+				continue
+			}
+			m.pinyinEntries = append(m.pinyinEntries, wordEntry{entry: words[word], matchType: detector.MatchTypePinyin})
+			pinyinPatterns = append(pinyinPatterns, full)
+
+			if initials, ok := pinyin.Initials(normalized); ok {
+				m.initials[initials] = append(m.initials[initials], wordEntry{entry: words[word], matchType: detector.MatchTypeInitials})
+			}
+		}
+	}
+	m.pinyinAutomaton = ahocorasick.Build(pinyinPatterns)
+
+	return m
+}
+
+// Update 从所有来源重新加载词库。来源加载失败时记录日志并使用该来源最近一次成功加载的内容，
+// 从未成功加载的来源本次跳过；合并后的词条减少比例超过上限时拒绝本次重载，保留当前版本并返回错误
 func (sw *SensitiveWords) Update() error {
-	newWords := make(map[string]bool)
+	sw.storeMu.Lock()
+	defer sw.storeMu.Unlock()
 
-	for _, path := range sw.filePaths {
-		if err := sw.loadFromFile(path, newWords); err != nil {
-			sw.logger.Warnf("Failed to load sensitive words from %s: %v", path, err)
-			// 继续加载其他文件
+	ctx, cancel := context.WithTimeout(context.Background(), wordSourceLoadTimeout)
+	defer cancel()
+
+	newWords := make(map[string]*dictEntry)
+	newCategories := make(map[string]*SensitiveWordCategory)
+	sourceCats := make(map[string]map[string]*SensitiveWordCategory, len(sw.sources))
+	for _, source := range sw.sources {
+		dict, err := source.Load(ctx)
+		if err != nil {
+			sw.mu.RLock()
+			snapshot := sw.snapshots[source.Name()]
+			sw.mu.RUnlock()
+			if snapshot == nil {
+				sw.logger.Errorf("Failed to load sensitive words from %s, skip it: %v", source.Name(), err)
+				continue
+			}
+			sw.logger.Warnf("Failed to load sensitive words from %s, using last good snapshot: %v", source.Name(), err)
+			dict = snapshot
+		} else {
+			sw.mu.Lock()
+			sw.snapshots[source.Name()] = dict
+			sw.mu.Unlock()
 		}
+		sw.mergeDict(source.Name(), dict, newWords, newCategories)
+		sourceCats[source.Name()] = dict.Categories
 	}
 
-	// 只有在成功加载至少一些词后才更新
-	if len(newWords) > 0 {
-		sw.mu.Lock()
-		sw.words = newWords
-		sw.mu.Unlock()
-		sw.logger.Infof("Loaded %d sensitive words", len(newWords))
-		return nil
+	newHomophones := make(map[string][]string)
+	for _, path := range sw.homophonePaths {
+		if err := sw.loadHomophones(path, newHomophones); err != nil && !os.IsNotExist(err) {
+			sw.logger.Warnf("Failed to load homophones from %s: %v", path, err)
+		}
+	}
+
+	newAllowlist := &Allowlist{}
+	for _, path := range sw.allowlistPaths {
+		if err := loadAllowlist(path, newAllowlist); err != nil && !os.IsNotExist(err) {
+			sw.logger.Warnf("Failed to load allowlist from %s: %v", path, err)
+		}
 	}
 
+	sw.mu.RLock()
+	oldCount := len(sw.words)
+	sw.mu.RUnlock()
+	if err := checkShrink(oldCount, len(newWords), sw.maxShrink); err != nil {
+		return fmt.Errorf("reject sensitive word reload: %w", err)
+	}
+
+	sw.install(newWords, newCategories, newHomophones, newAllowlist, "reload")
+
+	sw.mu.Lock()
+	sw.sourceCats = sourceCats
+	sw.mu.Unlock()
 	return nil
 }
 
-// loadFromFile 从文件加载敏感词
-func (sw *SensitiveWords) loadFromFile(path string, words map[string]bool) error {
+// mergeDict 解析来源中的词条并合并，无效词条记录日志后跳过
+func (sw *SensitiveWords) mergeDict(name string, dict *SensitiveWordDict, words map[string]*dictEntry, categories map[string]*SensitiveWordCategory) {
+	for category, c := range dict.Categories {
+		categories[category] = c
+	}
+
+	for _, entry := range dict.Words {
+		if entry == nil {
+			continue
+		}
+		resolved, err := resolveEntry(entry, dict.Categories)
+		if err != nil {
+			sw.logger.Warnf("Skip invalid sensitive word in %s: %v", name, err)
+			continue
+		}
+		resolved.source = name
+		words[resolved.Word] = resolved
+	}
+}
+
+// install 编译新词库并原子替换当前版本，记录版本号、校验和及变更明细
+func (sw *SensitiveWords) install(words map[string]*dictEntry, categories map[string]*SensitiveWordCategory, homophones map[string][]string, allowlist *Allowlist, reason string) {
+	checksum := wordListChecksum(words)
+
+	// 在锁外编译自动机，编译完成后原子替换
+	sw.mu.RLock()
+	oldWords := sw.words
+	oldVersion := sw.version
+	matcher := sw.buildMatcher(words, homophones)
+	allowMatcher := buildAllowMatcher(allowlist, sw.normalizer)
+	sw.mu.RUnlock()
+
+	version := oldVersion
+	if checksum != oldVersion.Checksum {
+		now := time.Now()
+		version = WordListVersion{
+			ID:       fmt.Sprintf("%s-%s", now.Format("20060102150405"), checksum[:8]),
+			Checksum: checksum,
+			Count:    len(words),
+			LoadedAt: now,
+		}
+	}
+
+	sw.mu.Lock()
+	sw.words = words
+	sw.categories = categories
+	sw.homophones = homophones
+	sw.allowlist = allowlist
+	sw.matcher = matcher
+	sw.allowMatcher = allowMatcher
+	sw.version = version
+	sw.mu.Unlock()
+
+	if checksum == oldVersion.Checksum {
+		sw.logger.Debugf("Sensitive words unchanged after %s, version %s", reason, version.ID)
+		return
+	}
+
+	diff := diffWordLists(oldWords, words)
+	sw.logger.Infow("Sensitive words updated",
+		"reason", reason,
+		"version", version.ID,
+		"previous_version", oldVersion.ID,
+		"checksum", checksum,
+		"count", len(words),
+		"added", len(diff.Added),
+		"removed", len(diff.Removed),
+		"changed", len(diff.Changed),
+		"added_words", sampleWords(diff.Added),
+		"removed_words", sampleWords(diff.Removed),
+		"changed_words", sampleWords(diff.Changed),
+	)
+}
+
+// Version 返回当前词库版本
+func (sw *SensitiveWords) Version() WordListVersion {
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	return sw.version
+}
+
+// Watch 监听支持变更通知的来源，来源变更时重新加载词库，ctx结束前阻塞。
+// 监听出错时按退避间隔重新监听，并在恢复后重新加载一次以补上中断期间的变更
+func (sw *SensitiveWords) Watch(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, source := range sw.sources {
+		watchable, ok := source.(WatchableWordSource)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sw.watchSource(ctx, watchable)
+		}()
+	}
+	wg.Wait()
+}
+
+// watchSource 持续监听单个来源，来源正常结束监听（如未开启监听）时返回
+func (sw *SensitiveWords) watchSource(ctx context.Context, source WatchableWordSource) {
+	reload := func() {
+		if err := sw.Update(); err != nil {
+			sw.logger.Errorf("Failed to reload sensitive words after %s changed: %v", source.Name(), err)
+		}
+	}
+
+	backoff := wordSourceWatchRetryMin
+	for {
+		started := time.Now()
+		err := source.Watch(ctx, reload)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		// 监听持续了较长时间后才出错，重新从最短间隔开始退避
+		if time.Since(started) > wordSourceWatchRetryMax {
+			backoff = wordSourceWatchRetryMin
+		}
+		sw.logger.Errorf("Watching %s failed, retry in %s: %v", source.Name(), backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > wordSourceWatchRetryMax {
+			backoff = wordSourceWatchRetryMax
+		}
+		reload()
+	}
+}
+
+// loadHomophones 从文件加载谐音变体，每行格式：原词: 变体1 变体2
+func (sw *SensitiveWords) loadHomophones(path string, homophones map[string][]string) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return err
@@ -73,50 +381,229 @@ func (sw *SensitiveWords) loadFromFile(path string, words map[string]bool) error
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		word := strings.TrimSpace(scanner.Text())
-		if word == "" || strings.HasPrefix(word, "#") {
-			continue // 跳过空行和注释
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(strings.ReplaceAll(line, "：", ":"), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		word := strings.TrimSpace(parts[0])
+		if word == "" {
+			continue
+		}
+		for _, variant := range strings.FieldsFunc(parts[1], func(r rune) bool {
+			return r == ' ' || r == ',' || r == '，' || r == '\t'
+		}) {
+			if variant != word {
+				homophones[word] = append(homophones[word], variant)
+			}
 		}
-		words[word] = true
 	}
 
 	return scanner.Err()
 }
 
-// AddWord 添加敏感词
+// AddWord 添加敏感词，已存在时保持原有配置
 func (sw *SensitiveWords) AddWord(word string) {
 	if word == "" {
 		return
 	}
 
-	sw.mu.Lock()
-	defer sw.mu.Unlock()
-	sw.words[word] = true
+	sw.mu.RLock()
+	_, exists := sw.words[word]
+	sw.mu.RUnlock()
+	if exists {
+		return
+	}
+
+	if _, err := sw.UpsertWords([]*SensitiveWordEntry{{Word: word}}); err != nil {
+		sw.logger.Warnf("Failed to add sensitive word %s: %v", word, err)
+	}
 }
 
 // RemoveWord 移除敏感词
 func (sw *SensitiveWords) RemoveWord(word string) {
-	sw.mu.Lock()
-	defer sw.mu.Unlock()
-	delete(sw.words, word)
+	if _, err := sw.RemoveWords([]string{word}, false); err != nil {
+		sw.logger.Warnf("Failed to remove sensitive word %s: %v", word, err)
+	}
 }
 
-// ContainsWord 检查内容是否包含敏感词
+// ContainsWord 检查原始内容是否包含敏感词（不区分场景），返回最先出现的敏感词
 func (sw *SensitiveWords) ContainsWord(content string) (bool, string) {
-	if content == "" {
+	ctx := &model.CheckContext{Content: content}
+
+	sw.mu.RLock()
+	norm := sw.normalizer
+	sw.mu.RUnlock()
+	if norm != nil {
+		normalized := norm.Normalize(content)
+		ctx.NormalizedContent = normalized.Text
+		ctx.NormalizedOffsets = normalized.Offsets
+	}
+
+	matches := sw.FindAllWords(ctx)
+	if len(matches) == 0 {
 		return false, ""
 	}
 
+	first := matches[0]
+	for _, m := range matches[1:] {
+		if m.Start < first.Start || (m.Start == first.Start && m.End > first.End) {
+			first = m
+		}
+	}
+
+	return true, first.Word
+}
+
+// FindAllWords 返回检查上下文中所有敏感词命中（含重叠命中），已排除被豁免短语覆盖的命中。
+// 匹配在归一化内容上进行，返回的位置已映射回原始内容
+func (sw *SensitiveWords) FindAllWords(ctx *model.CheckContext) []detector.WordMatch {
+	return sw.findWords(ctx, false)
+}
+
+// ExplainWords 返回包括被豁免命中在内的全部命中，被豁免的命中SuppressedBy为对应的豁免短语，
+// 供策略人员排查为何没有触发
+func (sw *SensitiveWords) ExplainWords(ctx *model.CheckContext) []detector.WordMatch {
+	return sw.findWords(ctx, true)
+}
+
+// findWords 执行匹配，includeSuppressed为false时丢弃被豁免的命中
+func (sw *SensitiveWords) findWords(ctx *model.CheckContext, includeSuppressed bool) []detector.WordMatch {
+	content := ctx.DetectContent()
+	if content == "" {
+		return nil
+	}
+
 	sw.mu.RLock()
-	defer sw.mu.RUnlock()
+	matcher := sw.matcher
+	allowMatcher := sw.allowMatcher
+	sw.mu.RUnlock()
 
-	for word := range sw.words {
-		if strings.Contains(content, word) {
-			return true, word
+	runes := []rune(content)
+	original := []rune(ctx.Content)
+	allowSpans := allowMatcher.find(content, ctx.Scene)
+
+	var matches []detector.WordMatch
+	exact := make(map[[2]int]map[string]bool)
+	add := func(we wordEntry, matchType string, start, end int) {
+		if !we.entry.appliesTo(ctx.Scene) {
+			return
+		}
+
+		origStart, origEnd := ctx.OriginalSpan(start, end)
+		switch we.entry.MatchMode {
+		case MatchModeExact:
+			if start != 0 || end != len(runes) {
+				return
+			}
+		case MatchModeWordBoundary:
+			// 归一化会去除空白和标点，边界需在原始内容上判断
+			if !isWordBoundary(original, origStart, origEnd) {
+				return
+			}
+		}
+
+		phrase := suppressedBy(allowSpans, start, end)
+		if phrase != "" && !includeSuppressed {
+			return
+		}
+
+		matches = append(matches, detector.WordMatch{
+			Word:         we.entry.Word,
+			Category:     we.entry.Category,
+			RiskType:     we.entry.riskType,
+			Score:        we.entry.Score,
+			MatchType:    matchType,
+			Start:        origStart,
+			End:          origEnd,
+			SuppressedBy: phrase,
+		})
+	}
+
+	// 1. 原词及谐音变体
+	for _, hit := range matcher.automaton.FindAll(content) {
+		we := matcher.entries[hit.Pattern]
+		add(we, we.matchType, hit.Start, hit.End)
+
+		span := [2]int{hit.Start, hit.End}
+		if exact[span] == nil {
+			exact[span] = make(map[string]bool)
+		}
+		exact[span][we.entry.Word] = true
+	}
+
+	if len(matcher.pinyinEntries) == 0 {
+		return matches
+	}
+
+	// 2. 全拼：在拼音流上匹配，命中须对齐音节边界
+	stream := pinyin.Transliterate(content)
+	for _, hit := range matcher.pinyinAutomaton.FindAll(stream.Text) {
+		if !stream.Boundaries[hit.Start] || !stream.Boundaries[hit.End] {
+			continue
+		}
+
+		start := stream.Offsets[hit.Start]
+		end := stream.Offsets[hit.End-1] + 1
+		we := matcher.pinyinEntries[hit.Pattern]
+		if exact[[2]int{start, end}][we.entry.Word] {
+			continue // 已作为原词或谐音变体命中
+		}
+
+		// 命中片段全部为汉字时视为同音字替换
+		matchType := detector.MatchTypeHomophone
+		for _, r := range runes[start:end] {
+			if !pinyin.IsHan(r) {
+				matchType = detector.MatchTypePinyin
+				break
+			}
+		}
+		add(we, matchType, start, end)
+	}
+
+	// 3. 首字母：须为完整的独立字母串，避免命中普通英文单词的片段
+	for i := 0; i < len(runes); {
+		if !isASCIILetter(runes[i]) {
+			i++
+			continue
 		}
+		j := i
+		for j < len(runes) && isASCIILetter(runes[j]) {
+			j++
+		}
+
+		for _, we := range matcher.initials[strings.ToLower(string(runes[i:j]))] {
+			add(we, we.matchType, i, j)
+		}
+		i = j
 	}
 
-	return false, ""
+	return matches
+}
+
+// isWordBoundary 判断[start, end)前后是否没有紧邻的字母或数字
+func isWordBoundary(runes []rune, start, end int) bool {
+	if start > 0 && isWordRune(runes[start-1]) {
+		return false
+	}
+	if end < len(runes) && isWordRune(runes[end]) {
+		return false
+	}
+	return true
+}
+
+// isWordRune 判断字符是否构成单词的一部分
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isASCIILetter 判断是否为ASCII字母
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
 }
 
 // GetAllWords 获取所有敏感词
@@ -134,20 +621,57 @@ func (sw *SensitiveWords) GetAllWords() []string {
 
 // SetWordList 设置敏感词列表
 func (sw *SensitiveWords) SetWordList(words []string) {
-	sw.mu.Lock()
-	defer sw.mu.Unlock()
-
-	sw.words = make(map[string]bool, len(words))
+	entries := make([]*SensitiveWordEntry, 0, len(words))
 	for _, word := range words {
-		if word != "" {
-			sw.words[word] = true
+		if strings.TrimSpace(word) != "" {
+			entries = append(entries, &SensitiveWordEntry{Word: word})
 		}
 	}
+
+	if _, err := sw.ImportWords(entries, true, false); err != nil {
+		sw.logger.Warnf("Failed to set sensitive word list: %v", err)
+	}
 }
 
-// AddFilePath 添加敏感词文件路径
+// SetNormalizer 设置词条归一化流水线并重新编译词库
+func (sw *SensitiveWords) SetNormalizer(norm *normalizer.Normalizer) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.normalizer = norm
+	sw.matcher = sw.buildMatcher(sw.words, sw.homophones)
+	sw.allowMatcher = buildAllowMatcher(sw.allowlist, norm)
+}
+
+// SetPinyinMatching 启用或关闭拼音、首字母及同音字匹配并重新编译词库
+func (sw *SensitiveWords) SetPinyinMatching(enabled bool) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.pinyinEnabled = enabled
+	sw.matcher = sw.buildMatcher(sw.words, sw.homophones)
+}
+
+// AddFilePath 添加敏感词文件来源
 func (sw *SensitiveWords) AddFilePath(path string) {
+	sw.AddSource(NewFileWordSource(path, false))
+}
+
+// AddSource 添加词库来源
+func (sw *SensitiveWords) AddSource(source WordSource) {
+	sw.storeMu.Lock()
+	defer sw.storeMu.Unlock()
+	sw.sources = append(sw.sources, source)
+}
+
+// AddHomophonePath 添加谐音变体文件路径
+func (sw *SensitiveWords) AddHomophonePath(path string) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.homophonePaths = append(sw.homophonePaths, path)
+}
+
+// AddAllowlistPath 添加豁免短语文件路径
+func (sw *SensitiveWords) AddAllowlistPath(path string) {
 	sw.mu.Lock()
 	defer sw.mu.Unlock()
-	sw.filePaths = append(sw.filePaths, path)
+	sw.allowlistPaths = append(sw.allowlistPaths, path)
 }
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 词条分页查询的每页条数
+const (
+	defaultWordPageSize = 50
+	maxWordPageSize     = 500
+)
+
+// wordPageSize 返回实际使用的每页条数，未指定时为默认值，超过上限时取上限
+func wordPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultWordPageSize
+	}
+	if pageSize > maxWordPageSize {
+		return maxWordPageSize
+	}
+	return pageSize
+}
+
+// ListWords 按关键字及类别分页查询词条，page从1开始，pageSize按 wordPageSize 限制，返回当前页词条及匹配总数
+func (sw *SensitiveWords) ListWords(query, category string, page, pageSize int) ([]SensitiveWordEntry, int) {
+	sw.mu.RLock()
+	matched := make([]SensitiveWordEntry, 0, len(sw.words))
+	for word, entry := range sw.words {
+		if query != "" && !strings.Contains(word, query) {
+			continue
+		}
+		if category != "" && entry.Category != category {
+			continue
+		}
+		matched = append(matched, entry.SensitiveWordEntry)
+	}
+	sw.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Word < matched[j].Word
+	})
+
+	total := len(matched)
+	if page < 1 {
+		page = 1
+	}
+	pageSize = wordPageSize(pageSize)
+
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []SensitiveWordEntry{}, total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	return matched[start:end], total
+}
+
+// UpsertWords 添加或更新词条并写回词库来源，任一词条校验失败时不做任何修改。
+// 所有词条都写入第一个可写来源
+func (sw *SensitiveWords) UpsertWords(entries []*SensitiveWordEntry) (int, error) {
+	return sw.applyChange(false, func(words map[string]*dictEntry, categories map[string]*SensitiveWordCategory) (int, error) {
+		resolved, err := resolveEntries(entries, categories)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range resolved {
+			words[entry.Word] = entry
+		}
+		return len(resolved), nil
+	})
+}
+
+// RemoveWords 删除词条并写回词库来源，返回实际删除的数量。force为true时跳过词条减少比例检查，
+// 用于删除最后一个词条或有意的批量删除
+func (sw *SensitiveWords) RemoveWords(words []string, force bool) (int, error) {
+	return sw.applyChange(force, func(current map[string]*dictEntry, _ map[string]*SensitiveWordCategory) (int, error) {
+		removed := 0
+		for _, word := range words {
+			word = strings.TrimSpace(word)
+			if _, ok := current[word]; ok {
+				delete(current, word)
+				removed++
+			}
+		}
+		return removed, nil
+	})
+}
+
+// ImportWords 批量导入词条并写回词库来源，replace为true时以导入内容替换整个词库，
+// force为true时跳过词条减少比例检查
+func (sw *SensitiveWords) ImportWords(entries []*SensitiveWordEntry, replace, force bool) (int, error) {
+	return sw.applyChange(force, func(words map[string]*dictEntry, categories map[string]*SensitiveWordCategory) (int, error) {
+		resolved, err := resolveEntries(entries, categories)
+		if err != nil {
+			return 0, err
+		}
+		if replace {
+			if len(resolved) == 0 {
+				return 0, fmt.Errorf("%w: refuse to replace sensitive words with an empty list", ErrInvalidRequest)
+			}
+			for word := range words {
+				delete(words, word)
+			}
+		}
+		for _, entry := range resolved {
+			words[entry.Word] = entry
+		}
+		return len(resolved), nil
+	})
+}
+
+// resolveEntries 校验并补全一批词条
+func resolveEntries(entries []*SensitiveWordEntry, categories map[string]*SensitiveWordCategory) ([]*dictEntry, error) {
+	resolved := make([]*dictEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		e, err := resolveEntry(entry, categories)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		resolved = append(resolved, e)
+	}
+	return resolved, nil
+}
+
+// applyChange 在词库副本上执行修改，force为false且词条减少比例超过上限时拒绝，写回第一个可写来源成功后再替换内存中的词库，
+// 保证下一次Update()加载到的内容与内存一致。仅写回属于该来源及新增的词条，
+// 删除其他来源的词条只在下一次重载前有效
+func (sw *SensitiveWords) applyChange(force bool, change func(words map[string]*dictEntry, categories map[string]*SensitiveWordCategory) (int, error)) (int, error) {
+	sw.storeMu.Lock()
+	defer sw.storeMu.Unlock()
+
+	var store WritableWordSource
+	for _, source := range sw.sources {
+		if writable, ok := source.(WritableWordSource); ok {
+			store = writable
+			break
+		}
+	}
+	if store == nil {
+		return 0, fmt.Errorf("no writable word source configured")
+	}
+
+	sw.mu.RLock()
+	words := make(map[string]*dictEntry, len(sw.words))
+	for word, entry := range sw.words {
+		words[word] = entry
+	}
+	categories := sw.categories
+	storeCategories := sw.sourceCats[store.Name()]
+	homophones := sw.homophones
+	allowlist := sw.allowlist
+	sw.mu.RUnlock()
+
+	oldCount := len(words)
+	affected, err := change(words, categories)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	if !force {
+		if err := checkShrink(oldCount, len(words), sw.maxShrink); err != nil {
+			return 0, fmt.Errorf("%w: %v, set force to apply anyway", ErrInvalidRequest, err)
+		}
+	}
+
+	// 新增的词条归属于写回的来源
+	for _, entry := range words {
+		if entry.source == "" {
+			entry.source = store.Name()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), wordSourceLoadTimeout)
+	defer cancel()
+	dict := buildDict(words, storeCategories, store.Name())
+	if err := store.Save(ctx, dict); err != nil {
+		return 0, fmt.Errorf("failed to save sensitive words to %s: %w", store.Name(), err)
+	}
+
+	sw.install(words, categories, homophones, allowlist, "admin")
+
+	// 写回的内容即该来源的最新快照，避免之后加载失败时回退到修改前的内容
+	sw.mu.Lock()
+	sw.snapshots[store.Name()] = dict
+	sw.mu.Unlock()
+	return affected, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aa12gq/content-risk-control/internal/pkg/ahocorasick"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+// Allowlist 敏感词豁免短语配置，命中落在更长的豁免短语内时不上报
+type Allowlist struct {
+	Global []string            `json:"global"` // 所有场景生效
+	Scenes map[string][]string `json:"scenes"` // 仅在对应场景生效
+}
+
+// allowMatcher 由豁免短语编译得到的只读匹配器
+type allowMatcher struct {
+	global        *ahocorasick.Automaton
+	globalPhrases []string
+	scenes        map[string]*ahocorasick.Automaton
+	scenePhrases  map[string][]string
+}
+
+// allowSpan 内容中豁免短语的出现位置（归一化内容中的rune偏移）
+type allowSpan struct {
+	phrase string
+	start  int
+	end    int
+}
+
+// buildAllowMatcher 编译豁免短语，norm不为空时短语先归一化
+func buildAllowMatcher(allowlist *Allowlist, norm *normalizer.Normalizer) *allowMatcher {
+	m := &allowMatcher{
+		scenes:       make(map[string]*ahocorasick.Automaton),
+		scenePhrases: make(map[string][]string),
+	}
+	if allowlist == nil {
+		return m
+	}
+
+	compile := func(phrases []string) (*ahocorasick.Automaton, []string) {
+		var kept []string
+		var patterns []string
+		for _, phrase := range phrases {
+			phrase = strings.TrimSpace(phrase)
+			if phrase == "" {
+				continue
+			}
+			pattern := phrase
+			if norm != nil {
+				if n := norm.String(phrase); n != "" {
+					pattern = n
+				}
+			}
+			kept = append(kept, phrase)
+			patterns = append(patterns, pattern)
+		}
+		return ahocorasick.Build(patterns), kept
+	}
+
+	m.global, m.globalPhrases = compile(allowlist.Global)
+	for scene, phrases := range allowlist.Scenes {
+		m.scenes[scene], m.scenePhrases[scene] = compile(phrases)
+	}
+
+	return m
+}
+
+// find 返回内容中全局及指定场景的豁免短语出现位置
+func (m *allowMatcher) find(content, scene string) []allowSpan {
+	if m == nil {
+		return nil
+	}
+
+	var spans []allowSpan
+	for _, hit := range m.global.FindAll(content) {
+		spans = append(spans, allowSpan{phrase: m.globalPhrases[hit.Pattern], start: hit.Start, end: hit.End})
+	}
+	if automaton, ok := m.scenes[scene]; ok {
+		phrases := m.scenePhrases[scene]
+		for _, hit := range automaton.FindAll(content) {
+			spans = append(spans, allowSpan{phrase: phrases[hit.Pattern], start: hit.Start, end: hit.End})
+		}
+	}
+
+	return spans
+}
+
+// suppressedBy 返回覆盖[start, end)且更长的豁免短语，未被豁免时返回空字符串
+func suppressedBy(spans []allowSpan, start, end int) string {
+	for _, span := range spans {
+		if span.start <= start && end <= span.end && span.end-span.start > end-start {
+			return span.phrase
+		}
+	}
+	return ""
+}
+
+// loadAllowlist 从JSON文件加载豁免短语并合并到allowlist
+func loadAllowlist(path string, allowlist *Allowlist) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var loaded Allowlist
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to unmarshal allowlist: %w", err)
+	}
+
+	allowlist.Global = append(allowlist.Global, loaded.Global...)
+	for scene, phrases := range loaded.Scenes {
+		if allowlist.Scenes == nil {
+			allowlist.Scenes = make(map[string][]string)
+		}
+		allowlist.Scenes[scene] = append(allowlist.Scenes[scene], phrases...)
+	}
+
+	return nil
+}
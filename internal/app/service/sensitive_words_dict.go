@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// 敏感词匹配模式
+const (
+	MatchModeSubstring    = "substring"     // 内容中任意位置出现即命中（默认）
+	MatchModeExact        = "exact"         // 内容整体与词条完全一致才命中，适用于昵称等短文本
+	MatchModeWordBoundary = "word_boundary" // 命中片段前后不能紧邻字母或数字，适用于英文缩写
+)
+
+const (
+	// defaultWordScore 未配置分数时的默认分数
+	defaultWordScore float32 = 80
+	// defaultWordRiskType 未配置风险类型时的默认风险类型
+	defaultWordRiskType = "sensitive_word"
+)
+
+// SensitiveWordCategory 敏感词类别，为类别下的词条提供默认配置
+type SensitiveWordCategory struct {
+	Name      string  `json:"name"`
+	RiskType  string  `json:"risk_type"`
+	Score     float32 `json:"score"`
+	MatchMode string  `json:"match_mode,omitempty"`
+}
+
+// SensitiveWordEntry 敏感词词条，未配置的字段继承所属类别
+type SensitiveWordEntry struct {
+	Word      string   `json:"word"`
+	Category  string   `json:"category"`
+	RiskType  string   `json:"risk_type,omitempty"`
+	Score     float32  `json:"score,omitempty"`
+	MatchMode string   `json:"match_mode,omitempty"`
+	Scenes    []string `json:"scenes,omitempty"` // 仅在指定场景生效，为空表示所有场景
+}
+
+// SensitiveWordDict 结构化敏感词词库
+type SensitiveWordDict struct {
+	Categories map[string]*SensitiveWordCategory `json:"categories"`
+	Words      []*SensitiveWordEntry             `json:"words"`
+}
+
+// dictEntry 解析完成的词条
+type dictEntry struct {
+	SensitiveWordEntry
+	raw      SensitiveWordEntry // 补全前的原始配置，持久化时写回
+	source   string             // 词条所属来源
+	riskType model.RiskType
+	scenes   map[string]bool
+}
+
+// appliesTo 判断词条在指定场景是否生效
+func (e *dictEntry) appliesTo(scene string) bool {
+	return len(e.scenes) == 0 || e.scenes[scene]
+}
+
+// resolveEntry 用类别配置补全词条并校验
+func resolveEntry(entry *SensitiveWordEntry, categories map[string]*SensitiveWordCategory) (*dictEntry, error) {
+	word := strings.TrimSpace(entry.Word)
+	if word == "" {
+		return nil, fmt.Errorf("empty word")
+	}
+
+	raw := *entry
+	raw.Word = word
+	resolved := raw
+	if resolved.Category == "" {
+		resolved.Category = defaultWordCategory
+	}
+
+	if c, ok := categories[resolved.Category]; ok {
+		if resolved.RiskType == "" {
+			resolved.RiskType = c.RiskType
+		}
+		if resolved.Score == 0 {
+			resolved.Score = c.Score
+		}
+		if resolved.MatchMode == "" {
+			resolved.MatchMode = c.MatchMode
+		}
+	}
+
+	if resolved.RiskType == "" {
+		resolved.RiskType = defaultWordRiskType
+	}
+	if resolved.Score == 0 {
+		resolved.Score = defaultWordScore
+	}
+	if resolved.MatchMode == "" {
+		resolved.MatchMode = MatchModeSubstring
+	}
+
+	riskType, ok := model.ParseRiskType(resolved.RiskType)
+	if !ok {
+		return nil, fmt.Errorf("word %q: unknown risk_type %q", word, resolved.RiskType)
+	}
+
+	switch resolved.MatchMode {
+	case MatchModeSubstring, MatchModeExact, MatchModeWordBoundary:
+	default:
+		return nil, fmt.Errorf("word %q: unknown match_mode %q", word, resolved.MatchMode)
+	}
+
+	if resolved.Score < 0 || resolved.Score > 100 {
+		return nil, fmt.Errorf("word %q: score %.2f out of range [0, 100]", word, resolved.Score)
+	}
+
+	var scenes map[string]bool
+	if len(resolved.Scenes) > 0 {
+		scenes = make(map[string]bool, len(resolved.Scenes))
+		for _, scene := range resolved.Scenes {
+			scenes[scene] = true
+		}
+	}
+
+	return &dictEntry{
+		SensitiveWordEntry: resolved,
+		raw:                raw,
+		riskType:           riskType,
+		scenes:             scenes,
+	}, nil
+}
+
+// isJSONDict 判断词库文件是否为结构化词库
+func isJSONDict(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// decodeDict 解析词库内容，asJSON为false时按纯文本词表解析：
+// 每行一个词条，词条归属于其上方最近一行注释所标注的类别
+func decodeDict(data []byte, asJSON bool) (*SensitiveWordDict, error) {
+	if asJSON {
+		var dict SensitiveWordDict
+		if err := json.Unmarshal(data, &dict); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sensitive word dict: %w", err)
+		}
+		return &dict, nil
+	}
+
+	dict := &SensitiveWordDict{}
+	category := defaultWordCategory
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue // 跳过空行
+		}
+		if strings.HasPrefix(word, "#") {
+			// 注释行作为后续词条的类别
+			if c := strings.TrimSpace(strings.TrimPrefix(word, "#")); c != "" {
+				category = c
+			}
+			continue
+		}
+		dict.Words = append(dict.Words, &SensitiveWordEntry{Word: word, Category: category})
+	}
+
+	return dict, scanner.Err()
+}
+
+// encodeDict 序列化词库，纯文本词表只能保存词条及类别
+func encodeDict(dict *SensitiveWordDict, asJSON bool) ([]byte, error) {
+	if asJSON {
+		data, err := json.MarshalIndent(dict, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal sensitive word dict: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+
+	var sb strings.Builder
+	for i, entry := range dict.Words {
+		category := entry.Category
+		if category == "" {
+			category = defaultWordCategory
+		}
+		if i == 0 || category != dict.Words[i-1].Category {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("# " + category + "\n")
+		}
+		sb.WriteString(entry.Word + "\n")
+	}
+	return []byte(sb.String()), nil
+}
+
+// buildDict 由解析后的词条还原指定来源的词库，词条按类别及词排序以保证输出稳定
+func buildDict(words map[string]*dictEntry, categories map[string]*SensitiveWordCategory, source string) *SensitiveWordDict {
+	entries := make([]*dictEntry, 0, len(words))
+	for _, entry := range words {
+		if entry.source == source {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Category != entries[j].Category {
+			return entries[i].Category < entries[j].Category
+		}
+		return entries[i].Word < entries[j].Word
+	})
+
+	dict := &SensitiveWordDict{
+		Categories: categories,
+		Words:      make([]*SensitiveWordEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		raw := entry.raw
+		if raw.Category == "" {
+			raw.Category = entry.Category
+		}
+		dict.Words = append(dict.Words, &raw)
+	}
+
+	return dict
+}
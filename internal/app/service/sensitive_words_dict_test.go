@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/detector"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+func TestResolveEntry(t *testing.T) {
+	categories := map[string]*SensitiveWordCategory{
+		"adult": {Name: "色情敏感词", RiskType: "adult", Score: 85},
+		"abbr":  {Name: "缩写", RiskType: "harassment", Score: 60, MatchMode: MatchModeWordBoundary},
+	}
+
+	tests := []struct {
+		name          string
+		entry         SensitiveWordEntry
+		wantCategory  string
+		wantRiskType  model.RiskType
+		wantScore     float32
+		wantMatchMode string
+		wantErr       string
+	}{
+		{
+			name:          "inherits category",
+			entry:         SensitiveWordEntry{Word: " 色情 ", Category: "adult"},
+			wantCategory:  "adult",
+			wantRiskType:  model.RiskTypeAdult,
+			wantScore:     85,
+			wantMatchMode: MatchModeSubstring,
+		},
+		{
+			name:          "entry overrides category",
+			entry:         SensitiveWordEntry{Word: "sb", Category: "abbr", RiskType: "hate_speech", Score: 70, MatchMode: MatchModeExact},
+			wantCategory:  "abbr",
+			wantRiskType:  model.RiskTypeHateSpeech,
+			wantScore:     70,
+			wantMatchMode: MatchModeExact,
+		},
+		{
+			name:          "category match mode",
+			entry:         SensitiveWordEntry{Word: "wtf", Category: "abbr"},
+			wantCategory:  "abbr",
+			wantRiskType:  model.RiskTypeHarassment,
+			wantScore:     60,
+			wantMatchMode: MatchModeWordBoundary,
+		},
+		{
+			name:          "defaults without category",
+			entry:         SensitiveWordEntry{Word: "词"},
+			wantCategory:  defaultWordCategory,
+			wantRiskType:  model.RiskTypeSensitiveWord,
+			wantScore:     defaultWordScore,
+			wantMatchMode: MatchModeSubstring,
+		},
+		{
+			name:          "unknown category uses defaults",
+			entry:         SensitiveWordEntry{Word: "词", Category: "missing"},
+			wantCategory:  "missing",
+			wantRiskType:  model.RiskTypeSensitiveWord,
+			wantScore:     defaultWordScore,
+			wantMatchMode: MatchModeSubstring,
+		},
+		{name: "empty word", entry: SensitiveWordEntry{Word: "  "}, wantErr: "empty word"},
+		{name: "unknown risk type", entry: SensitiveWordEntry{Word: "词", RiskType: "porn"}, wantErr: "unknown risk_type"},
+		{name: "unknown match mode", entry: SensitiveWordEntry{Word: "词", MatchMode: "regex"}, wantErr: "unknown match_mode"},
+		{name: "score out of range", entry: SensitiveWordEntry{Word: "词", Score: 120}, wantErr: "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := tt.entry
+			got, err := resolveEntry(&entry, categories)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveEntry error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveEntry: %v", err)
+			}
+			if got.Word != strings.TrimSpace(tt.entry.Word) || got.Category != tt.wantCategory ||
+				got.riskType != tt.wantRiskType || got.Score != tt.wantScore || got.MatchMode != tt.wantMatchMode {
+				t.Errorf("resolveEntry = %q %s %v %.0f %s, want %s %v %.0f %s",
+					got.Word, got.Category, got.riskType, got.Score, got.MatchMode,
+					tt.wantCategory, tt.wantRiskType, tt.wantScore, tt.wantMatchMode)
+			}
+			// 持久化时写回补全前的配置
+			if got.raw.Score != tt.entry.Score || got.raw.RiskType != tt.entry.RiskType {
+				t.Errorf("raw = %+v, want unresolved entry", got.raw)
+			}
+		})
+	}
+}
+
+func TestDecodeTextDict(t *testing.T) {
+	data := "词一\n\n# 色情敏感词\n词二\n#\n词三\n# 暴力敏感词\n  词四  \n"
+	dict, err := decodeDict([]byte(data), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SensitiveWordEntry{
+		{Word: "词一", Category: defaultWordCategory},
+		{Word: "词二", Category: "色情敏感词"},
+		{Word: "词三", Category: "色情敏感词"},
+		{Word: "词四", Category: "暴力敏感词"},
+	}
+	if len(dict.Words) != len(want) {
+		t.Fatalf("got %d words, want %d: %+v", len(dict.Words), len(want), dict.Words)
+	}
+	for i, w := range want {
+		if got := *dict.Words[i]; got.Word != w.Word || got.Category != w.Category {
+			t.Errorf("word %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if _, err := decodeDict([]byte("{"), true); err == nil {
+		t.Error("decodeDict with invalid JSON succeeded")
+	}
+}
+
+func TestSensitiveWordsDictMatching(t *testing.T) {
+	dict := &SensitiveWordDict{
+		Categories: map[string]*SensitiveWordCategory{
+			"adult":    {Name: "色情敏感词", RiskType: "adult", Score: 85},
+			"violence": {Name: "暴力敏感词", RiskType: "violence", Score: 80},
+		},
+		Words: []*SensitiveWordEntry{
+			{Word: "色情", Category: "adult"},
+			{Word: "裸聊", Category: "adult", Score: 95},
+			{Word: "砍人", Category: "violence"},
+			{Word: "管理员", Category: "violence", MatchMode: MatchModeExact},
+			{Word: "kys", Category: "violence", MatchMode: MatchModeWordBoundary},
+			{Word: "约炮", Category: "adult", Scenes: []string{"comment"}},
+		},
+	}
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{&staticWordSource{dict: dict}}, 0)
+	norm, err := normalizer.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw.SetNormalizer(norm)
+	d := detector.NewSensitiveWordDetector(sw)
+
+	type wantRisk struct {
+		riskType model.RiskType
+		score    float32
+	}
+	tests := []struct {
+		name    string
+		content string
+		scene   string
+		want    []wantRisk
+	}{
+		{"typed and scored by category", "这里有色情内容", "", []wantRisk{{model.RiskTypeAdult, 85}}},
+		{"highest entry score in category", "色情裸聊", "", []wantRisk{{model.RiskTypeAdult, 95}}},
+		{"one risk per category", "色情和砍人", "", []wantRisk{{model.RiskTypeAdult, 85}, {model.RiskTypeViolence, 80}}},
+		{"exact matches whole content", "管理员", "", []wantRisk{{model.RiskTypeViolence, 80}}},
+		{"exact ignores substring", "联系管理员", "", nil},
+		{"word boundary matches word", "just kys now", "", []wantRisk{{model.RiskTypeViolence, 80}}},
+		{"word boundary ignores inside word", "skyscraper", "", nil},
+		{"scene restricted word in scene", "约炮吗", "comment", []wantRisk{{model.RiskTypeAdult, 85}}},
+		{"scene restricted word elsewhere", "约炮吗", "chat", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := norm.Normalize(tt.content)
+			risks, err := d.Detect(context.Background(), &model.CheckContext{
+				Content:           tt.content,
+				Scene:             tt.scene,
+				NormalizedContent: res.Text,
+				NormalizedOffsets: res.Offsets,
+			})
+			if err != nil {
+				t.Fatalf("Detect: %v", err)
+			}
+			if len(risks) != len(tt.want) {
+				t.Fatalf("Detect(%q) = %d risks, want %d: %+v", tt.content, len(risks), len(tt.want), risks)
+			}
+			for i, w := range tt.want {
+				if risks[i].Type != w.riskType || risks[i].Score != w.score {
+					t.Errorf("risk %d = %v %.0f, want %v %.0f", i, risks[i].Type, risks[i].Score, w.riskType, w.score)
+				}
+			}
+		})
+	}
+}
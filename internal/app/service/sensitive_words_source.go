@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/aa12gq/content-risk-control/internal/app/config"
+)
+
+// 词库来源类型
+const (
+	WordSourceFile  = "file"
+	WordSourceHTTP  = "http"
+	WordSourceRedis = "redis"
+)
+
+const (
+	// defaultMaxShrinkPercent 未配置时允许单次重载减少的词条比例上限
+	defaultMaxShrinkPercent = 50
+	// wordSourceLoadTimeout 单次重载所有来源的超时时间
+	wordSourceLoadTimeout = 30 * time.Second
+	// maxDiffLogWords 变更日志中最多列出的词条数
+	maxDiffLogWords = 20
+)
+
+// 来源监听出错后重新监听的退避间隔
+var (
+	wordSourceWatchRetryMin = time.Second
+	wordSourceWatchRetryMax = time.Minute
+)
+
+// WordSource 敏感词词库来源
+type WordSource interface {
+	// Name 来源描述，用于日志
+	Name() string
+	// Load 加载完整词库
+	Load(ctx context.Context) (*SensitiveWordDict, error)
+}
+
+// WritableWordSource 支持写回的词库来源，管理接口的变更写入第一个可写来源
+type WritableWordSource interface {
+	WordSource
+	// Save 以dict覆盖来源中的词库
+	Save(ctx context.Context, dict *SensitiveWordDict) error
+}
+
+// WatchableWordSource 支持变更通知的词库来源
+type WatchableWordSource interface {
+	WordSource
+	// Watch 监听来源变更，变更时调用onChange，ctx结束前阻塞
+	Watch(ctx context.Context, onChange func()) error
+}
+
+// WordListVersion 词库版本信息
+type WordListVersion struct {
+	ID       string    `json:"id"`
+	Checksum string    `json:"checksum"`
+	Count    int       `json:"count"`
+	LoadedAt time.Time `json:"loaded_at"`
+}
+
+// WordListDiff 两个词库版本之间的差异
+type WordListDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string // 词条配置（类别、分数等）发生变化
+}
+
+// Empty 是否无差异
+func (d *WordListDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// NewWordSources 根据配置创建词库来源，未配置时使用本地结构化词库文件
+func NewWordSources(cfg []config.WordSourceConfig, redisClient *redis.Client) ([]WordSource, error) {
+	if len(cfg) == 0 {
+		return []WordSource{NewFileWordSource("config/sensitive_words.json", false)}, nil
+	}
+
+	sources := make([]WordSource, 0, len(cfg))
+	for i, c := range cfg {
+		switch c.Type {
+		case WordSourceFile, "":
+			if c.Path == "" {
+				return nil, fmt.Errorf("word source %d: path is required", i)
+			}
+			sources = append(sources, NewFileWordSource(c.Path, c.Watch))
+		case WordSourceHTTP:
+			if c.URL == "" {
+				return nil, fmt.Errorf("word source %d: url is required", i)
+			}
+			sources = append(sources, NewHTTPWordSource(c.URL, c.Headers, time.Duration(c.Timeout)*time.Millisecond))
+		case WordSourceRedis:
+			if c.Key == "" {
+				return nil, fmt.Errorf("word source %d: key is required", i)
+			}
+			if redisClient == nil {
+				return nil, fmt.Errorf("word source %d: redis client is not available", i)
+			}
+			sources = append(sources, NewRedisWordSource(redisClient, c.Key))
+		default:
+			return nil, fmt.Errorf("word source %d: unknown type %q", i, c.Type)
+		}
+	}
+
+	return sources, nil
+}
+
+// wordListChecksum 计算词库校验和，基于补全后的词条配置，与来源及加载顺序无关
+func wordListChecksum(words map[string]*dictEntry) string {
+	sorted := make([]string, 0, len(words))
+	for word := range words {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, word := range sorted {
+		data, _ := json.Marshal(words[word].SensitiveWordEntry)
+		h.Write(data)
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// diffWordLists 比较新旧词库
+func diffWordLists(oldWords, newWords map[string]*dictEntry) *WordListDiff {
+	diff := &WordListDiff{}
+	for word, entry := range newWords {
+		old, ok := oldWords[word]
+		if !ok {
+			diff.Added = append(diff.Added, word)
+			continue
+		}
+		oldData, _ := json.Marshal(old.SensitiveWordEntry)
+		newData, _ := json.Marshal(entry.SensitiveWordEntry)
+		if string(oldData) != string(newData) {
+			diff.Changed = append(diff.Changed, word)
+		}
+	}
+	for word := range oldWords {
+		if _, ok := newWords[word]; !ok {
+			diff.Removed = append(diff.Removed, word)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// checkShrink 词条减少比例超过maxPercent时拒绝重载
+func checkShrink(oldCount, newCount, maxPercent int) error {
+	if newCount == 0 {
+		return fmt.Errorf("new word list is empty")
+	}
+	if oldCount == 0 || newCount >= oldCount {
+		return nil
+	}
+
+	shrink := (oldCount - newCount) * 100 / oldCount
+	if shrink > maxPercent {
+		return fmt.Errorf("word list shrinks by %d%% (%d -> %d), exceeds limit %d%%", shrink, oldCount, newCount, maxPercent)
+	}
+	return nil
+}
+
+// sampleWords 截取日志中展示的词条
+func sampleWords(words []string) []string {
+	if len(words) > maxDiffLogWords {
+		return words[:maxDiffLogWords]
+	}
+	return words
+}
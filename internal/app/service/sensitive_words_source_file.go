@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"os"
+)
+
+// FileWordSource 本地文件词库来源，.json为结构化词库，其他按纯文本词表处理
+type FileWordSource struct {
+	path  string
+	watch bool
+}
+
+// NewFileWordSource 创建本地文件词库来源，watch为true时通过fsnotify监听文件变更
+func NewFileWordSource(path string, watch bool) *FileWordSource {
+	return &FileWordSource{
+		path:  path,
+		watch: watch,
+	}
+}
+
+// Name 来源描述
+func (s *FileWordSource) Name() string {
+	return "file:" + s.path
+}
+
+// Load 加载词库文件
+func (s *FileWordSource) Load(ctx context.Context) (*SensitiveWordDict, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDict(data, isJSONDict(s.path))
+}
+
+// Save 写回词库文件，先写临时文件再重命名，避免重载时读到不完整的内容
+func (s *FileWordSource) Save(ctx context.Context, dict *SensitiveWordDict) error {
+	data, err := encodeDict(dict, isJSONDict(s.path))
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path, data)
+}
+
+// Watch 文件被写入、创建或重命名替换时调用onChange
+func (s *FileWordSource) Watch(ctx context.Context, onChange func()) error {
+	if !s.watch {
+		return nil
+	}
+	return watchFile(ctx, s.path, onChange)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHTTPSourceTimeout 未配置时的HTTP请求超时时间
+const defaultHTTPSourceTimeout = 10 * time.Second
+
+// HTTPWordSource 远程HTTP词库来源，支持ETag条件请求，未变更时复用上次的内容
+type HTTPWordSource struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	mu   sync.Mutex
+	etag string
+	dict *SensitiveWordDict
+}
+
+// NewHTTPWordSource 创建HTTP词库来源，响应为JSON时按结构化词库解析，否则按纯文本词表解析
+func NewHTTPWordSource(url string, headers map[string]string, timeout time.Duration) *HTTPWordSource {
+	if timeout <= 0 {
+		timeout = defaultHTTPSourceTimeout
+	}
+	return &HTTPWordSource{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Name 来源描述
+func (s *HTTPWordSource) Name() string {
+	return "http:" + s.url
+}
+
+// Load 拉取远程词库
+func (s *HTTPWordSource) Load(ctx context.Context) (*SensitiveWordDict, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	if s.etag != "" && s.dict != nil {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch word list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && s.dict != nil {
+		return s.dict, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	asJSON := strings.Contains(resp.Header.Get("Content-Type"), "json") || isJSONDict(req.URL.Path)
+	dict, err := decodeDict(data, asJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	s.etag = resp.Header.Get("ETag")
+	s.dict = dict
+	return dict, nil
+}
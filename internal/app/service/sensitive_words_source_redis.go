@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisWordSource Redis哈希词库来源。
+// 词条存放在key中，field为敏感词，value为词条JSON（不含word字段）或类别名；
+// 类别配置存放在key:categories中，field为类别，value为类别JSON
+type RedisWordSource struct {
+	client        *redis.Client
+	key           string
+	categoriesKey string
+}
+
+// NewRedisWordSource 创建Redis词库来源
+func NewRedisWordSource(client *redis.Client, key string) *RedisWordSource {
+	return &RedisWordSource{
+		client:        client,
+		key:           key,
+		categoriesKey: key + ":categories",
+	}
+}
+
+// Name 来源描述
+func (s *RedisWordSource) Name() string {
+	return "redis:" + s.key
+}
+
+// Load 读取Redis中的词库
+func (s *RedisWordSource) Load(ctx context.Context) (*SensitiveWordDict, error) {
+	fields, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.key, err)
+	}
+
+	categories, err := s.client.HGetAll(ctx, s.categoriesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.categoriesKey, err)
+	}
+
+	dict := &SensitiveWordDict{
+		Categories: make(map[string]*SensitiveWordCategory, len(categories)),
+		Words:      make([]*SensitiveWordEntry, 0, len(fields)),
+	}
+	for name, value := range categories {
+		var category SensitiveWordCategory
+		if err := json.Unmarshal([]byte(value), &category); err != nil {
+			return nil, fmt.Errorf("invalid category %q: %w", name, err)
+		}
+		dict.Categories[name] = &category
+	}
+
+	for word, value := range fields {
+		entry := &SensitiveWordEntry{}
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "{") {
+			if err := json.Unmarshal([]byte(value), entry); err != nil {
+				return nil, fmt.Errorf("invalid word %q: %w", word, err)
+			}
+		} else {
+			entry.Category = value
+		}
+		entry.Word = word
+		dict.Words = append(dict.Words, entry)
+	}
+
+	return dict, nil
+}
+
+// Save 在事务中以dict整体替换Redis中的词库
+func (s *RedisWordSource) Save(ctx context.Context, dict *SensitiveWordDict) error {
+	words := make(map[string]interface{}, len(dict.Words))
+	for _, entry := range dict.Words {
+		value := *entry
+		value.Word = ""
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal word %q: %w", entry.Word, err)
+		}
+		words[entry.Word] = string(data)
+	}
+
+	categories := make(map[string]interface{}, len(dict.Categories))
+	for name, category := range dict.Categories {
+		data, err := json.Marshal(category)
+		if err != nil {
+			return fmt.Errorf("failed to marshal category %q: %w", name, err)
+		}
+		categories[name] = string(data)
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, s.key, s.categoriesKey)
+		if len(words) > 0 {
+			pipe.HSet(ctx, s.key, words)
+		}
+		if len(categories) > 0 {
+			pipe.HSet(ctx, s.categoriesKey, categories)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save word list to redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+// staticWordSource 返回固定词库的来源，可写回及监听
+type staticWordSource struct {
+	name string
+	mu   sync.Mutex
+	dict *SensitiveWordDict
+	err  error
+
+	loads   int
+	watches int
+	watch   func(ctx context.Context, attempt int, onChange func()) error
+}
+
+func (s *staticWordSource) Name() string {
+	if s.name == "" {
+		return "static"
+	}
+	return s.name
+}
+
+func (s *staticWordSource) Load(ctx context.Context) (*SensitiveWordDict, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	return s.dict, s.err
+}
+
+func (s *staticWordSource) Save(ctx context.Context, dict *SensitiveWordDict) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dict = dict
+	return nil
+}
+
+func (s *staticWordSource) Watch(ctx context.Context, onChange func()) error {
+	s.mu.Lock()
+	s.watches++
+	attempt := s.watches
+	s.mu.Unlock()
+	if s.watch == nil {
+		return nil
+	}
+	return s.watch(ctx, attempt, onChange)
+}
+
+func (s *staticWordSource) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *staticWordSource) counts() (loads, watches int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads, s.watches
+}
+
+// testDict 返回包含给定词条的词库
+func testDict(words ...string) *SensitiveWordDict {
+	dict := &SensitiveWordDict{}
+	for _, w := range words {
+		dict.Words = append(dict.Words, &SensitiveWordEntry{Word: w})
+	}
+	return dict
+}
+
+func newTestSensitiveWords(t *testing.T, words ...string) *SensitiveWords {
+	t.Helper()
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{&staticWordSource{dict: testDict(words...)}}, 0)
+	norm, err := normalizer.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw.SetNormalizer(norm)
+	sw.SetPinyinMatching(true)
+	return sw
+}
+
+func TestSensitiveWordsMixedScriptMatching(t *testing.T) {
+	sw := newTestSensitiveWords(t, "sb", "傻逼")
+
+	tests := []struct {
+		content   string
+		wantMatch bool
+	}{
+		{"this bad idea", false},
+		{"is bob here", false},
+		{"you are sb", true},
+		{"you are s b", true},
+		{"你真是个sb", true},
+		{"you are shabi", true},
+		{"你真是 shabi", true},
+		{"傻 逼", true},
+		{"this is fine", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			norm := sw.normalizer.Normalize(tt.content)
+			matches := sw.FindAllWords(&model.CheckContext{
+				Content:           tt.content,
+				NormalizedContent: norm.Text,
+				NormalizedOffsets: norm.Offsets,
+			})
+			if got := len(matches) > 0; got != tt.wantMatch {
+				t.Errorf("FindAllWords(%q) matched %v, want %v (matches: %+v)", tt.content, got, tt.wantMatch, matches)
+			}
+		})
+	}
+}
+
+func TestSensitiveWordsUpdateUsesLastGoodSnapshot(t *testing.T) {
+	good := &staticWordSource{name: "good", dict: testDict("alpha")}
+	flaky := &staticWordSource{name: "flaky", err: errors.New("unavailable")}
+
+	// 初次加载时失败的来源被跳过，其余来源正常加载
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{good, flaky}, 100)
+	if got := len(sw.GetAllWords()); got != 1 {
+		t.Fatalf("initial load has %d words, want 1", got)
+	}
+
+	flaky.mu.Lock()
+	flaky.dict, flaky.err = testDict("beta", "gamma"), nil
+	flaky.mu.Unlock()
+	if err := sw.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(sw.GetAllWords()); got != 3 {
+		t.Fatalf("after recovery has %d words, want 3", got)
+	}
+
+	// 来源再次失败时使用上次成功加载的内容
+	flaky.setErr(errors.New("unavailable"))
+	if err := sw.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(sw.GetAllWords()); got != 3 {
+		t.Fatalf("after failure has %d words, want 3", got)
+	}
+}
+
+func TestSensitiveWordsAdminShrinkGuard(t *testing.T) {
+	words := make([]string, 10)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	source := &staticWordSource{dict: testDict(words...)}
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{source}, 50)
+
+	if _, err := sw.RemoveWords(words[:6], false); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("removing 60%% of words: err = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := sw.ImportWords([]*SensitiveWordEntry{{Word: "only"}}, true, false); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("replacing with 1 word: err = %v, want ErrInvalidRequest", err)
+	}
+	if got := len(sw.GetAllWords()); got != 10 {
+		t.Fatalf("rejected edits changed the dictionary: %d words", got)
+	}
+
+	if removed, err := sw.RemoveWords(words[:3], false); err != nil || removed != 3 {
+		t.Fatalf("removing 30%% of words: removed %d, err %v", removed, err)
+	}
+
+	// 显式指定force时允许有意的批量删除及删除最后一个词条
+	if removed, err := sw.RemoveWords(words[3:9], true); err != nil || removed != 6 {
+		t.Fatalf("forced removal of 6 of 7 words: removed %d, err %v", removed, err)
+	}
+	if _, err := sw.RemoveWords(words[9:], false); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("removing the last word without force: err = %v, want ErrInvalidRequest", err)
+	}
+	if removed, err := sw.RemoveWords(words[9:], true); err != nil || removed != 1 {
+		t.Fatalf("forced removal of the last word: removed %d, err %v", removed, err)
+	}
+	if got := len(sw.GetAllWords()); got != 0 {
+		t.Fatalf("dictionary has %d words after removing all, want 0", got)
+	}
+	if n, err := sw.ImportWords([]*SensitiveWordEntry{{Word: "a"}, {Word: "b"}}, true, true); err != nil || n != 2 {
+		t.Fatalf("forced replace import: imported %d, err %v", n, err)
+	}
+}
+
+func TestSensitiveWordsWatchRetries(t *testing.T) {
+	oldMin, oldMax := wordSourceWatchRetryMin, wordSourceWatchRetryMax
+	wordSourceWatchRetryMin, wordSourceWatchRetryMax = time.Millisecond, 10*time.Millisecond
+	defer func() { wordSourceWatchRetryMin, wordSourceWatchRetryMax = oldMin, oldMax }()
+
+	source := &staticWordSource{dict: testDict("alpha")}
+	source.watch = func(ctx context.Context, attempt int, onChange func()) error {
+		if attempt < 3 {
+			return errors.New("watch failed")
+		}
+		<-ctx.Done()
+		return nil
+	}
+	sw := NewSensitiveWordsWithSources(zap.NewNop().Sugar(), []WordSource{source}, 0)
+	initialLoads, _ := source.counts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sw.Watch(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		loads, watches := source.counts()
+		if watches >= 3 && loads >= initialLoads+2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("watch was not retried: %d watches, %d reloads", watches, loads-initialLoads)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return after cancel")
+	}
+}
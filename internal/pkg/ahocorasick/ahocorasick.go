@@ -0,0 +1,151 @@
+package ahocorasick
+
+// Match 一次模式匹配结果，Start/End 为文本中的 rune 偏移（左闭右开）
+type Match struct {
+	Pattern int // 模式在构建时传入切片中的下标
+	Start   int
+	End     int
+}
+
+// node 自动机节点
+type node struct {
+	children map[rune]int32
+	fail     int32
+	outputs  []int // 以该节点结尾的所有模式（已合并失败链上的输出）
+}
+
+// Automaton Aho-Corasick 多模式匹配自动机，构建后只读，可并发使用
+type Automaton struct {
+	nodes    []node
+	patterns [][]rune
+}
+
+// Build 根据模式列表构建自动机，空模式会被忽略
+func Build(patterns []string) *Automaton {
+	a := &Automaton{
+		nodes:    []node{{children: make(map[rune]int32)}},
+		patterns: make([][]rune, len(patterns)),
+	}
+
+	// 1. 构建 Trie
+	for i, p := range patterns {
+		runes := []rune(p)
+		a.patterns[i] = runes
+		if len(runes) == 0 {
+			continue
+		}
+
+		cur := int32(0)
+		for _, r := range runes {
+			next, ok := a.nodes[cur].children[r]
+			if !ok {
+				next = int32(len(a.nodes))
+				a.nodes = append(a.nodes, node{children: make(map[rune]int32)})
+				a.nodes[cur].children[r] = next
+			}
+			cur = next
+		}
+		a.nodes[cur].outputs = append(a.nodes[cur].outputs, i)
+	}
+
+	// 2. BFS 计算失败指针
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].children {
+		a.nodes[child].fail = 0
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for r, child := range a.nodes[cur].children {
+			fail := a.nodes[cur].fail
+			for {
+				if next, ok := a.nodes[fail].children[r]; ok {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					a.nodes[child].fail = 0
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+
+			// 合并失败链上的输出，匹配时无需再沿失败链回溯
+			failOutputs := a.nodes[a.nodes[child].fail].outputs
+			if len(failOutputs) > 0 {
+				a.nodes[child].outputs = append(a.nodes[child].outputs, failOutputs...)
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return a
+}
+
+// PatternCount 返回模式数量
+func (a *Automaton) PatternCount() int {
+	return len(a.patterns)
+}
+
+// FindAll 返回文本中所有模式的全部出现位置（含重叠匹配），按结束位置升序
+func (a *Automaton) FindAll(text string) []Match {
+	if a == nil || len(a.nodes) <= 1 || text == "" {
+		return nil
+	}
+
+	var matches []Match
+	cur := int32(0)
+	pos := 0
+	for _, r := range text {
+		for {
+			if next, ok := a.nodes[cur].children[r]; ok {
+				cur = next
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = a.nodes[cur].fail
+		}
+
+		pos++
+		for _, idx := range a.nodes[cur].outputs {
+			matches = append(matches, Match{
+				Pattern: idx,
+				Start:   pos - len(a.patterns[idx]),
+				End:     pos,
+			})
+		}
+	}
+
+	return matches
+}
+
+// Contains 判断文本中是否存在任一模式
+func (a *Automaton) Contains(text string) bool {
+	if a == nil || len(a.nodes) <= 1 || text == "" {
+		return false
+	}
+
+	cur := int32(0)
+	for _, r := range text {
+		for {
+			if next, ok := a.nodes[cur].children[r]; ok {
+				cur = next
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = a.nodes[cur].fail
+		}
+		if len(a.nodes[cur].outputs) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
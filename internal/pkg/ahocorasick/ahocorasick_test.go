@@ -0,0 +1,106 @@
+package ahocorasick
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// naiveFindAll 逐位置比较的参考实现
+func naiveFindAll(patterns []string, text string) []Match {
+	runes := []rune(text)
+	var matches []Match
+	for end := 1; end <= len(runes); end++ {
+		for i, p := range patterns {
+			pr := []rune(p)
+			if len(pr) == 0 || len(pr) > end {
+				continue
+			}
+			if string(runes[end-len(pr):end]) == p {
+				matches = append(matches, Match{Pattern: i, Start: end - len(pr), End: end})
+			}
+		}
+	}
+	return matches
+}
+
+func sortMatches(matches []Match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].End != matches[j].End {
+			return matches[i].End < matches[j].End
+		}
+		return matches[i].Pattern < matches[j].Pattern
+	})
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		text     string
+		want     []Match
+	}{
+		{"classic overlap", []string{"he", "she", "his", "hers"}, "ushers", []Match{
+			{Pattern: 1, Start: 1, End: 4},
+			{Pattern: 0, Start: 2, End: 4},
+			{Pattern: 3, Start: 2, End: 6},
+		}},
+		{"chinese rune offsets", []string{"傻逼", "逼"}, "你真是个傻逼", []Match{
+			{Pattern: 0, Start: 4, End: 6},
+			{Pattern: 1, Start: 5, End: 6},
+		}},
+		{"repeated pattern", []string{"aa"}, "aaaa", []Match{
+			{Pattern: 0, Start: 0, End: 2},
+			{Pattern: 0, Start: 1, End: 3},
+			{Pattern: 0, Start: 2, End: 4},
+		}},
+		{"empty pattern ignored", []string{"", "b"}, "abc", []Match{{Pattern: 1, Start: 1, End: 2}}},
+		{"no match", []string{"foo"}, "bar", nil},
+		{"empty text", []string{"foo"}, "", nil},
+		{"no patterns", nil, "foo", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Build(tt.patterns)
+			got := a.FindAll(tt.text)
+			sortMatches(got)
+			sortMatches(tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			if contains := a.Contains(tt.text); contains != (len(tt.want) > 0) {
+				t.Errorf("Contains(%q) = %v, want %v", tt.text, contains, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestFindAllMatchesNaive(t *testing.T) {
+	patterns := []string{"ab", "abc", "bca", "c", "caab", "敏感", "感词", "敏感词"}
+	texts := []string{
+		"abcaabca",
+		"cccabcab",
+		"这是敏感词还是敏感的词",
+		strings.Repeat("abc敏感", 5),
+	}
+	a := Build(patterns)
+	if a.PatternCount() != len(patterns) {
+		t.Fatalf("PatternCount = %d, want %d", a.PatternCount(), len(patterns))
+	}
+	for _, text := range texts {
+		got, want := a.FindAll(text), naiveFindAll(patterns, text)
+		sortMatches(got)
+		sortMatches(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAll(%q) = %+v, want %+v", text, got, want)
+		}
+	}
+}
+
+func TestNilAutomaton(t *testing.T) {
+	var a *Automaton
+	if a.FindAll("text") != nil || a.Contains("text") {
+		t.Error("nil automaton should not match")
+	}
+}
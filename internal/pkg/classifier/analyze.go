@@ -0,0 +1,100 @@
+package classifier
+
+// AnalysisSimilarity 上下文相似度分析，与各分类任务一同作为 analysis_types 的取值
+const AnalysisSimilarity = "similarity"
+
+// sentiment 任务的正负面标签
+const (
+	SentimentPositive = "positive"
+	SentimentNegative = "negative"
+)
+
+// AnalyzeRequest 文本分析请求
+type AnalyzeRequest struct {
+	Text          string   `json:"text"`
+	Contexts      []string `json:"contexts,omitempty"`
+	AnalysisTypes []string `json:"analysis_types"` // intent, sentiment, toxicity, similarity，为空时执行全部
+}
+
+// Analysis 文本分析结果，模型不包含的任务及未请求的分析为空
+type Analysis struct {
+	ModelVersion string              `json:"model_version"`
+	Intent       *Prediction         `json:"intent,omitempty"`
+	Sentiment    *SentimentAnalysis  `json:"sentiment,omitempty"`
+	Toxicity     *ToxicityAnalysis   `json:"toxicity,omitempty"`
+	Similarity   *SimilarityAnalysis `json:"similarity,omitempty"`
+}
+
+// SentimentAnalysis 情感分析结果
+type SentimentAnalysis struct {
+	Prediction
+	Score     float64 `json:"score"`     // 正面概率减负面概率，范围 -1 ~ 1
+	Intensity float64 `json:"intensity"` // 情感强度，即 |score|
+}
+
+// ToxicityAnalysis 有害内容分析结果
+type ToxicityAnalysis struct {
+	Prediction
+	IsToxic    bool               `json:"is_toxic"`   // score 不低于0.5
+	Score      float64            `json:"score"`      // 有害概率，即 1 - P(none)
+	Categories map[string]float64 `json:"categories"` // 各有害类别的概率
+}
+
+// SimilarityAnalysis 与上下文消息的相似度
+type SimilarityAnalysis struct {
+	Scores  []float64 `json:"scores"`
+	Average float64   `json:"average"`
+}
+
+// Analyze 按请求的分析类型分析文本
+func (m *Model) Analyze(req AnalyzeRequest) *Analysis {
+	requested := func(kind string) bool {
+		if len(req.AnalysisTypes) == 0 {
+			return true
+		}
+		for _, t := range req.AnalysisTypes {
+			if t == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	analysis := &Analysis{ModelVersion: m.Version}
+	if requested(TaskIntent) {
+		analysis.Intent, _ = m.Predict(TaskIntent, req.Text)
+	}
+	if requested(TaskSentiment) {
+		if pred, ok := m.Predict(TaskSentiment, req.Text); ok {
+			score := pred.Scores[SentimentPositive] - pred.Scores[SentimentNegative]
+			intensity := score
+			if intensity < 0 {
+				intensity = -intensity
+			}
+			analysis.Sentiment = &SentimentAnalysis{Prediction: *pred, Score: score, Intensity: intensity}
+		}
+	}
+	if requested(TaskToxicity) {
+		if pred, ok := m.Predict(TaskToxicity, req.Text); ok {
+			toxicity := &ToxicityAnalysis{Prediction: *pred, Categories: make(map[string]float64)}
+			for label, p := range pred.Scores {
+				if label != ToxicityCleanLabel {
+					toxicity.Categories[label] = p
+					toxicity.Score += p
+				}
+			}
+			toxicity.IsToxic = toxicity.Score >= 0.5
+			analysis.Toxicity = toxicity
+		}
+	}
+	if requested(AnalysisSimilarity) && len(req.Contexts) > 0 {
+		similarity := &SimilarityAnalysis{Scores: make([]float64, len(req.Contexts))}
+		for i, c := range req.Contexts {
+			similarity.Scores[i] = m.Similarity(req.Text, c)
+			similarity.Average += similarity.Scores[i]
+		}
+		similarity.Average /= float64(len(req.Contexts))
+		analysis.Similarity = similarity
+	}
+	return analysis
+}
@@ -0,0 +1,317 @@
+package classifier
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+// 模型文件格式
+const (
+	FormatName    = "content-risk-classifier"
+	FormatVersion = 1
+)
+
+// 内置分类任务
+const (
+	TaskIntent    = "intent"
+	TaskSentiment = "sentiment"
+	TaskToxicity  = "toxicity"
+)
+
+// ToxicityCleanLabel toxicity 任务中表示无害的标签，其余标签为有害类别
+const ToxicityCleanLabel = "none"
+
+// DefaultNormalizerStages 特征提取前的默认归一化阶段，保留空白以区分英文单词边界
+var DefaultNormalizerStages = []string{
+	normalizer.StageNFKC,
+	normalizer.StageLowercase,
+	normalizer.StageT2S,
+	normalizer.StageZeroWidth,
+}
+
+// Model 基于字符n-gram的多项式朴素贝叶斯分类模型，每个任务为一个单标签分类器
+type Model struct {
+	Format        string           `json:"format"`
+	FormatVersion int              `json:"format_version"`
+	Version       string           `json:"version"` // 模型版本，由训练时指定
+	CreatedAt     time.Time        `json:"created_at"`
+	NGramMin      int              `json:"ngram_min"`
+	NGramMax      int              `json:"ngram_max"`
+	Normalizer    []string         `json:"normalizer"`
+	Tasks         map[string]*Task `json:"tasks"`
+
+	norm *normalizer.Normalizer
+}
+
+// Task 单个分类任务的参数
+type Task struct {
+	Labels      []string                      `json:"labels"`
+	Alpha       float64                       `json:"alpha"`       // 加法平滑系数
+	Temperature float64                       `json:"temperature"` // 概率校准温度，在留出集上拟合
+	DocCounts   map[string]int                `json:"doc_counts"`  // 各标签的样本数
+	TokenCounts map[string]map[string]float64 `json:"token_counts"`
+
+	// 预计算的对数概率
+	logPrior []float64
+	logProb  map[string][]float64 // n-gram在各标签下的对数概率
+}
+
+// Prediction 分类结果，Scores 为校准后各标签的概率
+type Prediction struct {
+	Label      string             `json:"label"`
+	Confidence float64            `json:"confidence"`
+	Scores     map[string]float64 `json:"scores"`
+}
+
+// Load 加载模型文件
+func Load(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("read model file: %w", err)
+	}
+	defer zr.Close()
+
+	var m Model
+	if err := json.NewDecoder(zr).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode model file: %w", err)
+	}
+	if m.Format != FormatName {
+		return nil, fmt.Errorf("unknown model format %q", m.Format)
+	}
+	if m.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported model format version %d, want %d", m.FormatVersion, FormatVersion)
+	}
+	if err := m.prepare(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Save 保存模型文件，先写入临时文件再替换，避免服务读到不完整的模型
+func (m *Model) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(m); err != nil {
+		tmp.Close()
+		return fmt.Errorf("encode model: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// TaskNames 返回模型包含的任务，按名称排序
+func (m *Model) TaskNames() []string {
+	names := make([]string, 0, len(m.Tasks))
+	for name := range m.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Predict 对文本执行指定任务的分类，模型不包含该任务时返回false
+func (m *Model) Predict(task, text string) (*Prediction, bool) {
+	t, ok := m.Tasks[task]
+	if !ok {
+		return nil, false
+	}
+	return t.predict(m.Features(text), t.Temperature), true
+}
+
+// Features 提取文本的字符n-gram及其出现次数
+func (m *Model) Features(text string) map[string]float64 {
+	if m.norm != nil {
+		text = m.norm.String(text)
+	}
+	runes := []rune(text)
+
+	features := make(map[string]float64)
+	for n := m.NGramMin; n <= m.NGramMax; n++ {
+		for i := 0; i+n <= len(runes); i++ {
+			features[string(runes[i:i+n])]++
+		}
+	}
+	return features
+}
+
+// Similarity 返回两段文本n-gram向量的余弦相似度
+func (m *Model) Similarity(a, b string) float64 {
+	fa, fb := m.Features(a), m.Features(b)
+	var dot, na, nb float64
+	for token, ca := range fa {
+		dot += ca * fb[token]
+		na += ca * ca
+	}
+	for _, cb := range fb {
+		nb += cb * cb
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// prepare 创建归一化流水线并预计算各任务的对数概率
+func (m *Model) prepare() error {
+	if m.NGramMin <= 0 || m.NGramMax < m.NGramMin {
+		return fmt.Errorf("invalid ngram range [%d, %d]", m.NGramMin, m.NGramMax)
+	}
+	norm, err := normalizer.New(m.Normalizer)
+	if err != nil {
+		return err
+	}
+	m.norm = norm
+
+	for name, t := range m.Tasks {
+		if err := validateLabels(name, t.Labels); err != nil {
+			return err
+		}
+		t.prepare()
+	}
+	return nil
+}
+
+// validateLabels 校验任务的标签集合，toxicity 任务须包含 ToxicityCleanLabel，
+// 否则所有样本都会被视为有害类别，有害概率恒为1
+func validateLabels(task string, labels []string) error {
+	if len(labels) < 2 {
+		return fmt.Errorf("task %s: need at least 2 labels, got %d", task, len(labels))
+	}
+	if task == TaskToxicity && !containsLabel(labels, ToxicityCleanLabel) {
+		return fmt.Errorf("task %s: label %q is required", task, ToxicityCleanLabel)
+	}
+	return nil
+}
+
+// containsLabel 判断标签集合是否包含label
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// prepare 预计算对数概率
+func (t *Task) prepare() {
+	var docs int
+	for _, label := range t.Labels {
+		docs += t.DocCounts[label]
+	}
+
+	vocab := make(map[string]bool)
+	for _, counts := range t.TokenCounts {
+		for token := range counts {
+			vocab[token] = true
+		}
+	}
+	v := float64(len(vocab))
+
+	k := len(t.Labels)
+	t.logPrior = make([]float64, k)
+	t.logProb = make(map[string][]float64, len(vocab))
+	for token := range vocab {
+		t.logProb[token] = make([]float64, k)
+	}
+
+	for i, label := range t.Labels {
+		// 先验同样做加法平滑，避免样本数为0的标签概率为0
+		t.logPrior[i] = math.Log((float64(t.DocCounts[label]) + 1) / (float64(docs) + float64(k)))
+
+		var total float64
+		for _, c := range t.TokenCounts[label] {
+			total += c
+		}
+		denom := math.Log(total + t.Alpha*v)
+		for token := range vocab {
+			t.logProb[token][i] = math.Log(t.TokenCounts[label][token]+t.Alpha) - denom
+		}
+	}
+}
+
+// logJoint 返回各标签的对数联合概率，词表外的n-gram忽略
+func (t *Task) logJoint(features map[string]float64) []float64 {
+	scores := append([]float64(nil), t.logPrior...)
+	for token, count := range features {
+		probs, ok := t.logProb[token]
+		if !ok {
+			continue
+		}
+		for i, p := range probs {
+			scores[i] += count * p
+		}
+	}
+	return scores
+}
+
+// predict 按温度校准后的softmax给出各标签概率
+func (t *Task) predict(features map[string]float64, temperature float64) *Prediction {
+	probs := softmax(t.logJoint(features), temperature)
+
+	pred := &Prediction{Scores: make(map[string]float64, len(t.Labels))}
+	for i, label := range t.Labels {
+		pred.Scores[label] = probs[i]
+		if probs[i] > pred.Confidence {
+			pred.Label, pred.Confidence = label, probs[i]
+		}
+	}
+	return pred
+}
+
+// softmax 计算 logits/temperature 的softmax
+func softmax(logits []float64, temperature float64) []float64 {
+	if temperature <= 0 {
+		temperature = 1
+	}
+	maxLogit := math.Inf(-1)
+	for _, l := range logits {
+		if l > maxLogit {
+			maxLogit = l
+		}
+	}
+
+	probs := make([]float64, len(logits))
+	var sum float64
+	for i, l := range logits {
+		probs[i] = math.Exp((l - maxLogit) / temperature)
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+	return probs
+}
@@ -0,0 +1,81 @@
+package classifier
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// trainSamplesPath 训练样本，与 cmd/train 的示例数据共用
+const trainSamplesPath = "../../../test/nlp_train_sample.jsonl"
+
+func loadTrainSamples(t *testing.T) []Example {
+	t.Helper()
+	f, err := os.Open(trainSamplesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	examples, err := ReadExamples(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return examples
+}
+
+func TestTrainRequiresToxicityCleanLabel(t *testing.T) {
+	examples := []Example{
+		{Text: "你这个废物", Labels: map[string]string{TaskToxicity: "insult"}},
+		{Text: "小心我找人收拾你", Labels: map[string]string{TaskToxicity: "threat"}},
+	}
+	_, _, err := Train(examples, TrainOptions{})
+	if err == nil || !strings.Contains(err.Error(), ToxicityCleanLabel) {
+		t.Fatalf("Train error = %v, want missing %q label", err, ToxicityCleanLabel)
+	}
+
+	// 其他任务不要求 none 标签
+	examples = []Example{
+		{Text: "你这个废物", Labels: map[string]string{TaskIntent: "insult"}},
+		{Text: "谢谢你的帮助", Labels: map[string]string{TaskIntent: "praise"}},
+	}
+	if _, _, err := Train(examples, TrainOptions{}); err != nil {
+		t.Fatalf("Train intent: %v", err)
+	}
+}
+
+func TestLoadRejectsToxicityWithoutCleanLabel(t *testing.T) {
+	m, _, err := Train(loadTrainSamples(t), TrainOptions{Version: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid")
+	if err := m.Save(valid); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(valid)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	analysis := loaded.Analyze(AnalyzeRequest{Text: "你这个废物，去死吧", AnalysisTypes: []string{TaskToxicity}})
+	if analysis.Toxicity == nil || analysis.Toxicity.Score < 0 || analysis.Toxicity.Score > 1 {
+		t.Fatalf("toxicity = %+v, want score in [0, 1]", analysis.Toxicity)
+	}
+
+	// 去掉 none 标签后的模型文件不能加载
+	toxicity := m.Tasks[TaskToxicity]
+	for i, label := range toxicity.Labels {
+		if label == ToxicityCleanLabel {
+			toxicity.Labels[i] = "clean"
+		}
+	}
+	invalid := filepath.Join(dir, "invalid")
+	if err := m.Save(invalid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(invalid); err == nil || !strings.Contains(err.Error(), ToxicityCleanLabel) {
+		t.Fatalf("Load error = %v, want missing %q label", err, ToxicityCleanLabel)
+	}
+}
@@ -0,0 +1,238 @@
+package classifier
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minCalibrationSamples 留出集样本数低于该值时不做概率校准（温度为1）
+const minCalibrationSamples = 10
+
+// Example 训练样本，Labels 为任务名到标签的映射，一条样本可只标注部分任务
+type Example struct {
+	Text   string            `json:"text"`
+	Labels map[string]string `json:"labels"`
+}
+
+// TrainOptions 训练参数
+type TrainOptions struct {
+	Version    string   // 模型版本，为空时使用训练时间
+	NGramMin   int      // 默认1
+	NGramMax   int      // 默认3
+	Alpha      float64  // 加法平滑系数，默认1
+	Holdout    float64  // 用于校准和评估的留出比例，默认0.2
+	Seed       int64    // 划分留出集的随机种子
+	Normalizer []string // 归一化阶段，为空时使用 DefaultNormalizerStages
+}
+
+// withDefaults 返回补全默认值后的参数
+func (o TrainOptions) withDefaults() TrainOptions {
+	if o.NGramMin <= 0 {
+		o.NGramMin = 1
+	}
+	if o.NGramMax <= 0 {
+		o.NGramMax = 3
+	}
+	if o.Alpha <= 0 {
+		o.Alpha = 1
+	}
+	if o.Holdout <= 0 || o.Holdout >= 1 {
+		o.Holdout = 0.2
+	}
+	if len(o.Normalizer) == 0 {
+		o.Normalizer = DefaultNormalizerStages
+	}
+	return o
+}
+
+// Evaluation 任务在留出集上的评估结果
+type Evaluation struct {
+	Task        string  `json:"task"`
+	Train       int     `json:"train"`
+	Holdout     int     `json:"holdout"`
+	Accuracy    float64 `json:"accuracy"`    // 留出集准确率
+	LogLoss     float64 `json:"log_loss"`    // 校准后的留出集平均负对数似然
+	Temperature float64 `json:"temperature"` // 拟合的校准温度
+}
+
+// ReadExamples 读取JSONL格式的训练样本，跳过空行
+func ReadExamples(r io.Reader) ([]Example, error) {
+	var examples []Example
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var ex Example
+		if err := json.Unmarshal([]byte(text), &ex); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if ex.Text == "" || len(ex.Labels) == 0 {
+			return nil, fmt.Errorf("line %d: text and labels are required", line)
+		}
+		examples = append(examples, ex)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return examples, nil
+}
+
+// Train 训练模型：每个任务先在训练集上训练并在留出集上拟合校准温度、评估效果，再用全部样本训练最终模型
+func Train(examples []Example, opts TrainOptions) (*Model, []Evaluation, error) {
+	opts = opts.withDefaults()
+	if opts.Version == "" {
+		opts.Version = time.Now().Format("20060102150405")
+	}
+
+	m := &Model{
+		Format:        FormatName,
+		FormatVersion: FormatVersion,
+		Version:       opts.Version,
+		CreatedAt:     time.Now(),
+		NGramMin:      opts.NGramMin,
+		NGramMax:      opts.NGramMax,
+		Normalizer:    opts.Normalizer,
+		Tasks:         make(map[string]*Task),
+	}
+	if err := m.prepare(); err != nil {
+		return nil, nil, err
+	}
+
+	// 按任务分组并提取特征
+	type sample struct {
+		features map[string]float64
+		label    string
+	}
+	byTask := make(map[string][]sample)
+	for _, ex := range examples {
+		features := m.Features(ex.Text)
+		for task, label := range ex.Labels {
+			if label != "" {
+				byTask[task] = append(byTask[task], sample{features: features, label: label})
+			}
+		}
+	}
+	if len(byTask) == 0 {
+		return nil, nil, fmt.Errorf("no labeled examples")
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	var evaluations []Evaluation
+	for _, task := range sortedKeys(byTask) {
+		samples := byTask[task]
+		labelSet := make(map[string]bool)
+		for _, s := range samples {
+			labelSet[s.label] = true
+		}
+		labels := sortedKeys(labelSet)
+		if err := validateLabels(task, labels); err != nil {
+			return nil, nil, err
+		}
+
+		rng.Shuffle(len(samples), func(i, j int) { samples[i], samples[j] = samples[j], samples[i] })
+		split := len(samples) - int(float64(len(samples))*opts.Holdout)
+		train, holdout := samples[:split], samples[split:]
+
+		fit := func(samples []sample) *Task {
+			t := &Task{
+				Labels:      labels,
+				Alpha:       opts.Alpha,
+				Temperature: 1,
+				DocCounts:   make(map[string]int),
+				TokenCounts: make(map[string]map[string]float64),
+			}
+			for _, label := range labels {
+				t.TokenCounts[label] = make(map[string]float64)
+			}
+			for _, s := range samples {
+				t.DocCounts[s.label]++
+				for token, count := range s.features {
+					t.TokenCounts[s.label][token] += count
+				}
+			}
+			t.prepare()
+			return t
+		}
+
+		eval := Evaluation{Task: task, Train: len(train), Holdout: len(holdout), Temperature: 1}
+		if len(holdout) > 0 {
+			t := fit(train)
+			logits := make([][]float64, len(holdout))
+			targets := make([]int, len(holdout))
+			for i, s := range holdout {
+				logits[i] = t.logJoint(s.features)
+				targets[i] = sort.SearchStrings(labels, s.label)
+			}
+			if len(holdout) >= minCalibrationSamples {
+				eval.Temperature = fitTemperature(logits, targets)
+			}
+
+			var correct int
+			for i := range holdout {
+				probs := softmax(logits[i], eval.Temperature)
+				if argmax(probs) == targets[i] {
+					correct++
+				}
+				eval.LogLoss -= math.Log(math.Max(probs[targets[i]], 1e-12))
+			}
+			eval.Accuracy = float64(correct) / float64(len(holdout))
+			eval.LogLoss /= float64(len(holdout))
+		}
+		evaluations = append(evaluations, eval)
+
+		final := fit(samples)
+		final.Temperature = eval.Temperature
+		m.Tasks[task] = final
+	}
+
+	return m, evaluations, nil
+}
+
+// fitTemperature 在对数尺度的网格上搜索使留出集负对数似然最小的温度
+func fitTemperature(logits [][]float64, targets []int) float64 {
+	best, bestLoss := 1.0, math.Inf(1)
+	for i := 0; i <= 80; i++ {
+		temperature := math.Pow(10, -1+float64(i)*0.05) // 0.1 ~ 1000
+		var loss float64
+		for j, l := range logits {
+			loss -= math.Log(math.Max(softmax(l, temperature)[targets[j]], 1e-12))
+		}
+		if loss < bestLoss {
+			best, bestLoss = temperature, loss
+		}
+	}
+	return best
+}
+
+// argmax 返回最大值的下标
+func argmax(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v > values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// sortedKeys 返回map的键，按字典序排序
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
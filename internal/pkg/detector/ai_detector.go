@@ -2,6 +2,7 @@ package detector
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -49,6 +50,27 @@ type AIRiskItem struct {
 	Details     map[string]string `json:"details,omitempty"`
 }
 
+// aiOptions AI检测器实例配置
+type aiOptions struct {
+	URL     string `mapstructure:"url"`
+	APIKey  string `mapstructure:"api_key"`
+	Timeout int    `mapstructure:"timeout"` // 毫秒
+}
+
+func init() {
+	Register("ai", func(opts Options, _ Dependencies) (Detector, error) {
+		var o aiOptions
+		if err := opts.Decode(&o); err != nil {
+			return nil, fmt.Errorf("ai detector: %w", err)
+		}
+		detector, err := NewAIDetector(o.URL, o.APIKey, time.Duration(o.Timeout)*time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+		return detector, nil
+	})
+}
+
 // NewAIDetector 创建AI检测器
 func NewAIDetector(url, apiKey string, timeout time.Duration) (*AIDetector, error) {
 	if url == "" {
@@ -68,22 +90,22 @@ func NewAIDetector(url, apiKey string, timeout time.Duration) (*AIDetector, erro
 }
 
 // Detect 使用AI检测内容风险
-func (d *AIDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
-	if ctx.Content == "" {
+func (d *AIDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
 		return nil, nil
 	}
 
 	// 准备请求体
 	reqBody := AIRequestBody{
-		Content:     ctx.Content,
-		UserID:      ctx.UserID,
-		ExtraParams: ctx.ExtraData,
+		Content:     checkCtx.Content,
+		UserID:      checkCtx.UserID,
+		ExtraParams: checkCtx.ExtraData,
 	}
 
 	// 添加上下文
-	if len(ctx.ContextItems) > 0 {
-		reqBody.Context = make([]*AIContextItem, 0, len(ctx.ContextItems))
-		for _, item := range ctx.ContextItems {
+	if len(checkCtx.ContextItems) > 0 {
+		reqBody.Context = make([]*AIContextItem, 0, len(checkCtx.ContextItems))
+		for _, item := range checkCtx.ContextItems {
 			reqBody.Context = append(reqBody.Context, &AIContextItem{
 				Content:   item.Content,
 				UserID:    item.UserID,
@@ -99,7 +121,7 @@ func (d *AIDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error)
 	}
 
 	// 创建请求
-	req, err := http.NewRequest("POST", d.url, bytes.NewBuffer(reqData))
+	req, err := http.NewRequestWithContext(ctx, "POST", d.url, bytes.NewBuffer(reqData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create AI request: %w", err)
 	}
@@ -170,6 +192,8 @@ func (d *AIDetector) mapRiskType(aiRiskType string) model.RiskType {
 		return model.RiskTypeContextViolation
 	case "suspicious_behavior":
 		return model.RiskTypeSuspiciousBehavior
+	case "self_harm":
+		return model.RiskTypeSelfHarm
 	default:
 		return model.RiskTypeUnknown
 	}
@@ -0,0 +1,221 @@
+package detector
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState 熔断器状态
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 正常调用
+	BreakerOpen                         // 熔断中，不调用外部服务
+	BreakerHalfOpen                     // 冷却结束，放行探测请求
+)
+
+// String 返回状态名称
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig 熔断器配置，时间单位为毫秒，未配置的项使用默认值
+type BreakerConfig struct {
+	FailureRatio   float64 `mapstructure:"failure_ratio"`    // 统计窗口内失败率达到该值时熔断，默认0.5
+	MinRequests    int     `mapstructure:"min_requests"`     // 统计窗口内请求数达到该值才判断失败率，默认5
+	Window         int     `mapstructure:"window"`           // 统计窗口，默认30000
+	Cooldown       int     `mapstructure:"cooldown"`         // 熔断后进入半开状态前的冷却时间，默认30000
+	HalfOpenProbes int     `mapstructure:"half_open_probes"` // 半开状态放行的探测请求数，全部成功后恢复，默认1
+}
+
+// withDefaults 返回补全默认值后的配置
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	if c.FailureRatio <= 0 {
+		c.FailureRatio = 0.5
+	}
+	if c.MinRequests <= 0 {
+		c.MinRequests = 5
+	}
+	if c.Window <= 0 {
+		c.Window = 30000
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = 30000
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = 1
+	}
+	return c
+}
+
+// BreakerStatus 熔断器状态快照
+type BreakerStatus struct {
+	State    string     `json:"state"`
+	Requests int        `json:"requests"` // 当前统计窗口内的请求数
+	Failures int        `json:"failures"` // 当前统计窗口内的失败数
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker 熔断器，统计窗口内失败率过高时熔断，冷却后放行探测请求，探测成功则恢复
+type CircuitBreaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int // 半开状态已放行的探测请求数
+	successes   int // 半开状态成功的探测请求数
+}
+
+// NewCircuitBreaker 创建熔断器
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	b := &CircuitBreaker{
+		cfg: cfg.withDefaults(),
+		now: time.Now,
+	}
+	b.windowStart = b.now()
+	return b
+}
+
+// Allow 判断是否放行本次调用，放行后须调用 Success、Failure 或 Release 之一
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case BreakerClosed:
+		if now.Sub(b.windowStart) >= b.duration(b.cfg.Window) {
+			b.resetWindow(now)
+		}
+		return true
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.duration(b.cfg.Cooldown) {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probes, b.successes = 0, 0
+	}
+
+	if b.probes >= b.cfg.HalfOpenProbes {
+		return false
+	}
+	b.probes++
+	return true
+}
+
+// Success 记录调用成功
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		b.requests++
+	case BreakerHalfOpen:
+		b.successes++
+		if b.successes >= b.cfg.HalfOpenProbes {
+			b.state = BreakerClosed
+			b.resetWindow(b.now())
+		}
+	}
+}
+
+// Failure 记录调用失败，半开状态下任一探测失败即重新熔断
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		b.requests++
+		b.failures++
+		if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+			b.open()
+		}
+	case BreakerHalfOpen:
+		b.open()
+	}
+}
+
+// Release 放弃本次调用的结果（如调用方取消），不计入统计
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// Trip 直接熔断，用于启动时连接测试失败等场景
+func (b *CircuitBreaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.open()
+}
+
+// State 返回当前状态，冷却结束但尚未有请求时仍为open
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Status 返回状态快照
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		State:    b.state.String(),
+		Requests: b.requests,
+		Failures: b.failures,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// open 切换到熔断状态，调用方需持有锁
+func (b *CircuitBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+	b.probes, b.successes = 0, 0
+}
+
+// resetWindow 开始新的统计窗口，调用方需持有锁
+func (b *CircuitBreaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests, b.failures = 0, 0
+}
+
+// duration 将毫秒数转换为时长
+func (b *CircuitBreaker) duration(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// CircuitBreaking 带熔断器的检测器
+type CircuitBreaking interface {
+	CircuitBreaker() *CircuitBreaker
+}
+
+// MultiCircuitBreaking 带多个熔断器的检测器，如集成检测器的各成员，按名称索引
+type MultiCircuitBreaking interface {
+	CircuitBreakers() map[string]*CircuitBreaker
+}
@@ -0,0 +1,147 @@
+package detector
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(ms int) { c.t = c.t.Add(time.Duration(ms) * time.Millisecond) }
+
+// newTestBreaker 创建使用 fakeClock 的熔断器
+func newTestBreaker(cfg BreakerConfig) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	b := NewCircuitBreaker(cfg)
+	b.now = clock.now
+	b.windowStart = clock.now()
+	return b, clock
+}
+
+// breakerStep 熔断器操作：allow 调用 Allow 并校验返回值，其余分别调用 Success、Failure、Release、Trip 或推进时钟
+type breakerStep struct {
+	op        string
+	wantAllow bool
+	advance   int
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	cfg := BreakerConfig{FailureRatio: 0.5, MinRequests: 2, Window: 1000, Cooldown: 500, HalfOpenProbes: 1}
+	allow := breakerStep{op: "allow", wantAllow: true}
+	deny := breakerStep{op: "allow", wantAllow: false}
+	success := breakerStep{op: "success"}
+	failure := breakerStep{op: "failure"}
+	wait := func(ms int) breakerStep { return breakerStep{op: "wait", advance: ms} }
+
+	tests := []struct {
+		name  string
+		cfg   BreakerConfig
+		steps []breakerStep
+		want  BreakerState
+	}{
+		{
+			name:  "stays closed below min requests",
+			steps: []breakerStep{allow, failure},
+			want:  BreakerClosed,
+		},
+		{
+			name:  "stays closed below failure ratio",
+			cfg:   BreakerConfig{FailureRatio: 0.5, MinRequests: 3, Window: 1000, Cooldown: 500},
+			steps: []breakerStep{allow, success, allow, success, allow, failure},
+			want:  BreakerClosed,
+		},
+		{
+			name:  "opens at failure ratio",
+			steps: []breakerStep{allow, success, allow, failure, deny},
+			want:  BreakerOpen,
+		},
+		{
+			name:  "window reset forgets old failures",
+			steps: []breakerStep{allow, failure, wait(1000), allow, failure},
+			want:  BreakerClosed,
+		},
+		{
+			name:  "half open after cooldown",
+			steps: []breakerStep{allow, failure, allow, failure, wait(499), deny, wait(1), allow},
+			want:  BreakerHalfOpen,
+		},
+		{
+			name:  "half open limits probes",
+			steps: []breakerStep{allow, failure, allow, failure, wait(500), allow, deny},
+			want:  BreakerHalfOpen,
+		},
+		{
+			name:  "probe success closes",
+			steps: []breakerStep{allow, failure, allow, failure, wait(500), allow, success, allow},
+			want:  BreakerClosed,
+		},
+		{
+			name:  "probe failure reopens",
+			steps: []breakerStep{allow, failure, allow, failure, wait(500), allow, failure, deny},
+			want:  BreakerOpen,
+		},
+		{
+			name:  "released probe can be retried",
+			steps: []breakerStep{allow, failure, allow, failure, wait(500), allow, {op: "release"}, allow, success},
+			want:  BreakerClosed,
+		},
+		{
+			name:  "closes only after all probes succeed",
+			cfg:   BreakerConfig{FailureRatio: 0.5, MinRequests: 1, Window: 1000, Cooldown: 500, HalfOpenProbes: 2},
+			steps: []breakerStep{allow, failure, wait(500), allow, allow, deny, success},
+			want:  BreakerHalfOpen,
+		},
+		{
+			name:  "trip opens immediately",
+			steps: []breakerStep{{op: "trip"}, deny, wait(500), allow},
+			want:  BreakerHalfOpen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cfg
+			if tt.cfg != (BreakerConfig{}) {
+				c = tt.cfg
+			}
+			b, clock := newTestBreaker(c)
+			for i, step := range tt.steps {
+				switch step.op {
+				case "allow":
+					if got := b.Allow(); got != step.wantAllow {
+						t.Fatalf("step %d: Allow() = %v, want %v", i, got, step.wantAllow)
+					}
+				case "success":
+					b.Success()
+				case "failure":
+					b.Failure()
+				case "release":
+					b.Release()
+				case "trip":
+					b.Trip()
+				case "wait":
+					clock.advance(step.advance)
+				}
+			}
+			if got := b.State(); got != tt.want {
+				t.Errorf("state = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerStatus(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{MinRequests: 3})
+	b.Allow()
+	b.Failure()
+	if s := b.Status(); s.State != "closed" || s.Requests != 1 || s.Failures != 1 || s.OpenedAt != nil {
+		t.Errorf("status = %+v, want closed with one failure", s)
+	}
+
+	b.Trip()
+	if s := b.Status(); s.State != "open" || s.OpenedAt == nil {
+		t.Errorf("status = %+v, want open with opened_at", s)
+	}
+}
@@ -1,11 +1,13 @@
 package detector
 
 import (
+	"context"
+
 	"github.com/aa12gq/content-risk-control/internal/app/model"
 )
 
 // Detector 内容检测器接口
 type Detector interface {
-	// Detect 检测内容
-	Detect(ctx *model.CheckContext) ([]*model.RiskItem, error)
+	// Detect 检测内容，ctx结束（超时或调用方取消）时应尽快返回ctx.Err()
+	Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error)
 }
@@ -0,0 +1,356 @@
+package detector
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// 集成检测器的合并策略
+const (
+	EnsembleStrategyMajority      = "majority"       // 多数投票，风险分数取多数一方的平均值
+	EnsembleStrategyMean          = "mean"           // 平均风险分数
+	EnsembleStrategyMinConfidence = "min_confidence" // 只采信置信度不低于 min_confidence 的成员，取其平均风险分数
+)
+
+var (
+	// ensembleMemberStats 各成员的投票统计，按“实例名.成员名”记录 votes（有效结果）、agree（与集成结论一致）、
+	// disagree、errors，通过 /debug/vars 暴露，agree/votes 即成员与集成结论的一致率
+	ensembleMemberStats = expvar.NewMap("llm_ensemble_members")
+	// ensembleDecisions 集成结论计数: agreed（一致）, escalated（分歧升级人工审核）, insufficient（有效结果不足）
+	ensembleDecisions = expvar.NewMap("llm_ensemble_decisions")
+
+	ensembleStatsMu sync.Mutex
+)
+
+// ensembleOptionKeys 集成检测器自身的配置项，其余配置项作为成员的共用配置
+var ensembleOptionKeys = map[string]bool{
+	"strategy":       true,
+	"max_spread":     true,
+	"min_confidence": true,
+	"min_votes":      true,
+	"members":        true,
+}
+
+// EnsembleConfig 多模型集成检测器配置
+type EnsembleConfig struct {
+	LLMConfig     `mapstructure:",squash"` // 成员共用的配置，threshold、categories 同时用于集成结论
+	Strategy      string                   `mapstructure:"strategy"`       // majority（默认）, mean, min_confidence
+	MaxSpread     float32                  `mapstructure:"max_spread"`     // 成员风险分数（0-1）的极差超过该值时升级人工审核，默认0.4
+	MinConfidence float32                  `mapstructure:"min_confidence"` // min_confidence 策略采信成员的最低置信度（|2×风险分数-1|），默认0.5
+	MinVotes      int                      `mapstructure:"min_votes"`      // 至少需要的有效结果数，不足时检测失败，默认为2与成员数中的较小值
+	Members       []Options                `mapstructure:"members"`        // 成员配置，name 为成员名，其余项覆盖共用配置
+	Name          string                   `mapstructure:"-"`              // 检测器实例名，成员统计按实例区分，为空时为 ensemble
+}
+
+// ensembleMember 集成检测器的成员
+type ensembleMember struct {
+	name     string
+	detector *LLMDetector
+	stats    *expvar.Map
+}
+
+// memberVote 成员的分析结果
+type memberVote struct {
+	member *ensembleMember
+	result *SemanticAnalysisResult
+	err    error
+}
+
+// toxic 成员是否判定有害
+func (v memberVote) toxic(threshold float32) bool {
+	return v.result.IsToxic && v.result.Risk > threshold
+}
+
+// EnsembleDetector 多模型集成检测器，并行调用多个大语言模型后端或提示词，按策略合并结论，成员分歧过大时升级人工审核
+type EnsembleDetector struct {
+	cfg        EnsembleConfig
+	members    []*ensembleMember
+	categories []llmCategory
+}
+
+func init() {
+	Register("ensemble", func(opts Options, deps Dependencies) (Detector, error) {
+		var cfg EnsembleConfig
+		if err := opts.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("ensemble detector: %w", err)
+		}
+		cfg.Name = deps.Instance
+
+		// 成员继承共用配置
+		for i, member := range cfg.Members {
+			merged := Options{}
+			for k, v := range opts {
+				if !ensembleOptionKeys[k] {
+					merged[k] = v
+				}
+			}
+			for k, v := range member {
+				merged[k] = v
+			}
+			cfg.Members[i] = merged
+		}
+		return NewEnsembleDetector(cfg)
+	})
+}
+
+// NewEnsembleDetector 创建多模型集成检测器，cfg.Members 为各成员完整的 llm 配置项
+func NewEnsembleDetector(cfg EnsembleConfig) (*EnsembleDetector, error) {
+	if cfg.Strategy == "" {
+		cfg.Strategy = EnsembleStrategyMajority
+	}
+	switch cfg.Strategy {
+	case EnsembleStrategyMajority, EnsembleStrategyMean, EnsembleStrategyMinConfidence:
+	default:
+		return nil, fmt.Errorf("%w: unknown ensemble strategy %q", ErrInvalidOptions, cfg.Strategy)
+	}
+	if cfg.MaxSpread <= 0 {
+		cfg.MaxSpread = 0.4
+	}
+	if cfg.MinConfidence <= 0 {
+		cfg.MinConfidence = 0.5
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = 0.5
+	}
+	if len(cfg.Members) < 2 {
+		return nil, fmt.Errorf("%w: ensemble requires at least 2 members", ErrInvalidOptions)
+	}
+	if cfg.MinVotes <= 0 {
+		cfg.MinVotes = 2
+	}
+	if cfg.MinVotes > len(cfg.Members) {
+		cfg.MinVotes = len(cfg.Members)
+	}
+	if cfg.Name == "" {
+		cfg.Name = "ensemble"
+	}
+
+	categories, err := buildLLMCategories(cfg.LLMConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &EnsembleDetector{cfg: cfg, categories: categories}
+	seen := make(map[string]bool, len(cfg.Members))
+	for i, opts := range cfg.Members {
+		name, _ := opts["name"].(string)
+		memberOpts := Options{}
+		for k, v := range opts {
+			if k != "name" {
+				memberOpts[k] = v
+			}
+		}
+
+		var memberCfg LLMConfig
+		if err := memberOpts.Decode(&memberCfg); err != nil {
+			return nil, fmt.Errorf("ensemble member %d: %w", i, err)
+		}
+		if name == "" {
+			name = memberCfg.withDefaults().Model
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate ensemble member %q", ErrInvalidOptions, name)
+		}
+		seen[name] = true
+
+		detector, err := NewLLMDetector(memberCfg)
+		if err != nil {
+			return nil, fmt.Errorf("ensemble member %s: %w", name, err)
+		}
+		d.members = append(d.members, &ensembleMember{name: name, detector: detector, stats: memberStats(cfg.Name + "." + name)})
+	}
+
+	return d, nil
+}
+
+// memberStats 返回成员的投票统计，key 为“实例名.成员名”
+func memberStats(key string) *expvar.Map {
+	ensembleStatsMu.Lock()
+	defer ensembleStatsMu.Unlock()
+
+	if stats, ok := ensembleMemberStats.Get(key).(*expvar.Map); ok {
+		return stats
+	}
+	stats := new(expvar.Map)
+	ensembleMemberStats.Set(key, stats)
+	return stats
+}
+
+// CircuitBreakers 返回各成员的熔断器，按成员名索引
+func (d *EnsembleDetector) CircuitBreakers() map[string]*CircuitBreaker {
+	breakers := make(map[string]*CircuitBreaker, len(d.members))
+	for _, m := range d.members {
+		breakers[m.name] = m.detector.CircuitBreaker()
+	}
+	return breakers
+}
+
+// Detect 并行调用各成员并合并结论
+func (d *EnsembleDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
+		return nil, nil
+	}
+
+	votes := make([]memberVote, len(d.members))
+	var wg sync.WaitGroup
+	for i, m := range d.members {
+		wg.Add(1)
+		go func(i int, m *ensembleMember) {
+			defer wg.Done()
+			result, err := m.detector.verdict(ctx, checkCtx)
+			votes[i] = memberVote{member: m, result: result, err: err}
+		}(i, m)
+	}
+	wg.Wait()
+
+	var valid []memberVote
+	var failed []string
+	canaryMismatch := false
+	for _, v := range votes {
+		if v.err != nil {
+			v.member.stats.Add("errors", 1)
+			failed = append(failed, v.member.name)
+			canaryMismatch = canaryMismatch || errors.Is(v.err, errCanaryMismatch)
+			continue
+		}
+		v.member.stats.Add("votes", 1)
+		valid = append(valid, v)
+	}
+
+	var risks []*model.RiskItem
+	if canaryMismatch {
+		// 任一成员的输出被内容操纵即视为可疑
+		risks = append(risks, canaryMismatchRisk())
+	}
+	if len(valid) < d.cfg.MinVotes {
+		ensembleDecisions.Add("insufficient", 1)
+		if len(risks) > 0 {
+			return risks, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ensemble got %d valid verdicts, need %d (failed: %s)", len(valid), d.cfg.MinVotes, strings.Join(failed, ","))
+	}
+
+	combined, spread, escalate := d.combine(valid)
+	for _, v := range valid {
+		if combined != nil && v.toxic(d.cfg.Threshold) == combined.IsToxic {
+			v.member.stats.Add("agree", 1)
+		} else {
+			v.member.stats.Add("disagree", 1)
+		}
+	}
+
+	if escalate {
+		ensembleDecisions.Add("escalated", 1)
+		return append(risks, d.escalationRisk(valid, spread)), nil
+	}
+	ensembleDecisions.Add("agreed", 1)
+	return append(risks, verdictRisks(combined, d.categories, d.cfg.Threshold)...), nil
+}
+
+// combine 按策略合并成员结论，返回合并结果、成员风险分数的极差及是否需要升级人工审核
+func (d *EnsembleDetector) combine(votes []memberVote) (*SemanticAnalysisResult, float32, bool) {
+	minRisk, maxRisk := votes[0].result.Risk, votes[0].result.Risk
+	for _, v := range votes[1:] {
+		if v.result.Risk < minRisk {
+			minRisk = v.result.Risk
+		}
+		if v.result.Risk > maxRisk {
+			maxRisk = v.result.Risk
+		}
+	}
+	spread := maxRisk - minRisk
+	escalate := spread > d.cfg.MaxSpread
+
+	switch d.cfg.Strategy {
+	case EnsembleStrategyMajority:
+		var toxic, clean []memberVote
+		for _, v := range votes {
+			if v.toxic(d.cfg.Threshold) {
+				toxic = append(toxic, v)
+			} else {
+				clean = append(clean, v)
+			}
+		}
+		switch {
+		case len(toxic) > len(clean):
+			return meanVerdict(toxic, true), spread, escalate
+		case len(clean) > len(toxic):
+			return meanVerdict(clean, false), spread, escalate
+		default:
+			// 票数相同
+			return nil, spread, true
+		}
+
+	case EnsembleStrategyMinConfidence:
+		var confident []memberVote
+		for _, v := range votes {
+			confidence := 2*v.result.Risk - 1
+			if confidence < 0 {
+				confidence = -confidence
+			}
+			if confidence >= d.cfg.MinConfidence {
+				confident = append(confident, v)
+			}
+		}
+		if len(confident) == 0 {
+			return nil, spread, true
+		}
+		votes = confident
+	}
+
+	combined := meanVerdict(votes, false)
+	combined.IsToxic = combined.Risk > d.cfg.Threshold
+	return combined, spread, escalate
+}
+
+// meanVerdict 取各成员风险分数及类别分数的平均值，说明取风险分数最高的成员
+func meanVerdict(votes []memberVote, toxic bool) *SemanticAnalysisResult {
+	combined := &SemanticAnalysisResult{IsToxic: toxic, Categories: make(map[string]float32)}
+	var top *SemanticAnalysisResult
+	for _, v := range votes {
+		combined.Risk += v.result.Risk
+		for category, score := range v.result.Categories {
+			combined.Categories[category] += score
+		}
+		if top == nil || v.result.Risk > top.Risk {
+			top = v.result
+		}
+	}
+
+	n := float32(len(votes))
+	combined.Risk /= n
+	for category := range combined.Categories {
+		combined.Categories[category] /= n
+	}
+	combined.Explanation = top.Explanation
+	combined.Intent = top.Intent
+	combined.Sentiment = top.Sentiment
+	return combined
+}
+
+// escalationRisk 成员分歧时输出的风险项，分数为成员风险分数的平均值，详情记录各成员的风险分数；
+// 该风险项不参与分数聚合，仅使结果至少为人工审核
+func (d *EnsembleDetector) escalationRisk(votes []memberVote, spread float32) *model.RiskItem {
+	var mean float32
+	for _, v := range votes {
+		mean += v.result.Risk
+	}
+	mean /= float32(len(votes))
+
+	riskItem := model.NewRiskItem(model.RiskTypeEscalation, mean*100, "多个模型的判定不一致，需人工审核")
+	riskItem.Details["strategy"] = d.cfg.Strategy
+	riskItem.Details["spread"] = fmt.Sprintf("%.2f", spread)
+	for _, v := range votes {
+		riskItem.Details["model."+v.member.name] = fmt.Sprintf("%.2f", v.result.Risk)
+	}
+	return riskItem
+}
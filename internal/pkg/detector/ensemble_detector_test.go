@@ -0,0 +1,52 @@
+package detector
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+const cleanVerdict = `{"is_toxic":false,"categories":{"insult":0.05},"explanation":"正常内容","intent":"neutral","sentiment":"neutral","risk_score":0.1,"canary":"$CANARY"}`
+
+func TestEnsembleDetectorEscalationAndMemberStats(t *testing.T) {
+	strict := newFakeLLMServer(t, threatVerdict, nil)
+	lenient := newFakeLLMServer(t, cleanVerdict, nil)
+
+	d, err := DefaultRegistry().Create("ensemble", Options{
+		"threshold": 0.5,
+		"members": []interface{}{
+			map[string]interface{}{"name": "strict", "base_url": strict.URL},
+			map[string]interface{}{"name": "lenient", "base_url": lenient.URL},
+		},
+	}, Dependencies{Instance: "review_ensemble"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	risks, err := d.Detect(context.Background(), &model.CheckContext{Content: "小心我找人收拾你"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(risks) != 1 || risks[0].Type != model.RiskTypeEscalation {
+		t.Fatalf("risks = %+v, want one escalation risk", risks)
+	}
+	if risks[0].Details["model.strict"] != "0.90" || risks[0].Details["model.lenient"] != "0.10" {
+		t.Errorf("escalation details = %v", risks[0].Details)
+	}
+
+	// 成员统计按实例名区分
+	for _, key := range []string{"review_ensemble.strict", "review_ensemble.lenient"} {
+		stats, ok := ensembleMemberStats.Get(key).(*expvar.Map)
+		if !ok {
+			t.Fatalf("no member stats for %s", key)
+		}
+		if votes, _ := stats.Get("votes").(*expvar.Int); votes == nil || votes.Value() != 1 {
+			t.Errorf("%s votes = %v, want 1", key, stats.Get("votes"))
+		}
+	}
+	if ensembleMemberStats.Get("strict") != nil {
+		t.Error("member stats keyed by member name only")
+	}
+}
@@ -0,0 +1,44 @@
+package detector
+
+import (
+	"unicode"
+
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+// textFoldStages 正则类检测器使用的折叠阶段：折叠全角、大小写、形近字符、繁体及零宽字符。
+// 不使用 separator 阶段：去除空白和标点后相邻单词及句子会拼接在一起，
+// 使特征跨越句子边界误命中（如 "之后，果汁" 折叠为 "之后果汁" 后命中 "后果"）
+var textFoldStages = []string{
+	normalizer.StageNFKC,
+	normalizer.StageLowercase,
+	normalizer.StageHomoglyph,
+	normalizer.StageT2S,
+	normalizer.StageZeroWidth,
+}
+
+// textFolder 按 textFoldStages 折叠文本，阶段均为内置阶段，创建不会失败
+var textFolder, _ = normalizer.New(textFoldStages)
+
+// foldText 折叠内容并去除汉字两侧的空白，还原逐字拆开的中文写法（如 "忽 略 指 令"），其余空白合并为一个空格
+func foldText(content string) string {
+	runes := []rune(textFolder.String(content))
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); {
+		if !unicode.IsSpace(runes[i]) {
+			out = append(out, runes[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && unicode.IsSpace(runes[j]) {
+			j++
+		}
+		if len(out) > 0 && j < len(runes) && !unicode.Is(unicode.Han, out[len(out)-1]) && !unicode.Is(unicode.Han, runes[j]) {
+			out = append(out, ' ')
+		}
+		i = j
+	}
+	return string(out)
+}
@@ -1,6 +1,7 @@
 package detector
 
 import (
+	"context"
 	"strings"
 
 	"github.com/aa12gq/content-risk-control/internal/app/model"
@@ -20,19 +21,26 @@ var (
 // HarassmentDetector 骚扰内容检测器
 type HarassmentDetector struct{}
 
+func init() {
+	Register("harassment", func(Options, Dependencies) (Detector, error) {
+		return NewHarassmentDetector(), nil
+	})
+}
+
 // NewHarassmentDetector 创建骚扰内容检测器
 func NewHarassmentDetector() *HarassmentDetector {
 	return &HarassmentDetector{}
 }
 
 // Detect 检测内容是否为骚扰内容
-func (d *HarassmentDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
-	if ctx.Content == "" {
+func (d *HarassmentDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
 		return nil, nil
 	}
 
 	var risks []*model.RiskItem
-	content := strings.ToLower(ctx.Content)
+	// 不使用 NormalizedContent：其中的分隔字符已被去除，关键词会跨越句子边界
+	content := foldText(checkCtx.Content)
 
 	// 1. 检查是否包含骚扰关键词
 	for _, keyword := range harassmentKeywords {
@@ -50,9 +58,9 @@ func (d *HarassmentDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem,
 	}
 
 	// 2. 检查上下文中是否有骚扰模式
-	if len(ctx.ContextItems) > 0 {
+	if len(checkCtx.ContextItems) > 0 {
 		// 检查是否有重复消息模式
-		if sameUserRepeatCount := d.countSameUserRepeatMessages(ctx); sameUserRepeatCount >= repeatMessageThreshold {
+		if sameUserRepeatCount := d.countSameUserRepeatMessages(checkCtx); sameUserRepeatCount >= repeatMessageThreshold {
 			risks = append(risks, &model.RiskItem{
 				Type:        model.RiskTypeHarassment,
 				Score:       65.0,
@@ -64,7 +72,7 @@ func (d *HarassmentDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem,
 		}
 
 		// 检查是否针对特定用户频繁发送消息
-		if isTargeting, targetUser := d.isTargetingUser(ctx); isTargeting {
+		if isTargeting, targetUser := d.isTargetingUser(checkCtx); isTargeting {
 			risks = append(risks, &model.RiskItem{
 				Type:        model.RiskTypeHarassment,
 				Score:       60.0,
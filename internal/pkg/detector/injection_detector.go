@@ -0,0 +1,127 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// InjectionPatternConfig 提示词注入特征配置
+type InjectionPatternConfig struct {
+	Name    string  `mapstructure:"name"`
+	Pattern string  `mapstructure:"pattern"` // 正则表达式，同时匹配原始内容及折叠后的内容（见 textFoldStages）
+	Score   float32 `mapstructure:"score"`   // 命中时的风险分数（0-100），默认60
+}
+
+// InjectionConfig 提示词注入检测器配置
+type InjectionConfig struct {
+	Patterns []InjectionPatternConfig `mapstructure:"patterns"` // 追加的注入特征
+}
+
+// defaultInjectionPatterns 内置注入特征，英文特征以 \b 限定单词边界，中文特征不跨越标点
+var defaultInjectionPatterns = []InjectionPatternConfig{
+	// 要求模型忽略已有指令
+	{"ignore_instructions", `(?i)\b(ignore|disregard|forget|override|bypass)\s+((all|any|the|your)\s+)*(previous|prior|above|earlier|preceding|system|original)\s+(instructions?|prompts?|rules?|directions?|guidelines?)\b`, 80},
+	{"ignore_instructions_zh", `(忽略|无视|忘记|忘掉|不要理会|不用理会|跳过|覆盖)(掉)?(你)?((之前|以上|上面|前面|先前|此前|原来|原有|系统|所有|全部|一切)(的)?(所有|全部|一切)?(的)?(指令|指示|提示词|规则|设定|要求|命令)|的(设定|指令|提示词))`, 80},
+	// 重新设定模型身份
+	{"role_override", `(?i)\b((you\s+are\s+now|from\s+now\s+on\s*,?\s*you\s+are|pretend\s+(to\s+be|you\s+are)|act\s+as)\s+((a|an|the)\s+)?(\w+\s+){0,2}(assistant|model|ai|bot|moderator|classifier|dan)\b|new\s+instructions?\s*:)`, 50},
+	{"role_override_zh", `(?i)((你现在是|从现在开始你是|从现在起你是|假装你是|扮演)(一个|一名|一位)?[^，。、；：,.;:!?！？\s]{0,8}(助手|模型|机器人|审核员|分类器|ai)|你的新(身份|角色|任务)是|新的指令[:：])`, 50},
+	// 伪造或指定审核结果
+	{"verdict_forgery", `(?i)"?\b(is_toxic|risk_score)"?\s*[:=]\s*"?(false|0)\b`, 80},
+	{"verdict_request", `(?i)\b(return|output|respond\s+with|answer|classify\s+(this|it|me)\s+as|mark\s+(this|it)\s+as)(\s+|\s*:\s*)(as\s+)?(safe|harmless|non\s*-?\s*toxic|not\s+toxic|clean|benign)\b`, 70},
+	{"verdict_request_zh", `(把|将)?(这段|这条|本条|该条?|以下|上述|我的)(文本|内容|消息|评论|话)?(判定|判断|标记|认定|归类|分类|评定)(为|成)(安全|无害|正常|合规|不违规|无风险)`, 70},
+	// 试探系统提示词或越狱
+	{"prompt_probe", `(?i)(\bsystem\s+prompt\b|\bdeveloper\s+mode\b|\bjailbreak|系统提示词|开发者模式|越狱模式)`, 40},
+	// 对话模板中的角色标记
+	{"role_marker", `(?im)(<\|im_(start|end)\|>|<\|(system|assistant|user)\|>|\[/?INST\]|<</?SYS>>|^\s*#{2,}\s*(system|instruction)|^\s*(system|assistant)\s*:)`, 70},
+}
+
+// injectionPattern 编译后的注入特征
+type injectionPattern struct {
+	name  string
+	re    *regexp.Regexp
+	score float32
+}
+
+// InjectionDetector 提示词注入检测器，检测试图操纵审核模型的内容
+type InjectionDetector struct {
+	patterns []injectionPattern
+}
+
+func init() {
+	Register("injection", func(opts Options, _ Dependencies) (Detector, error) {
+		var cfg InjectionConfig
+		if err := opts.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("injection detector: %w", err)
+		}
+		return NewInjectionDetector(cfg)
+	})
+}
+
+// NewInjectionDetector 创建提示词注入检测器
+func NewInjectionDetector(cfg InjectionConfig) (*InjectionDetector, error) {
+	d := &InjectionDetector{}
+	for _, p := range append(append([]InjectionPatternConfig{}, defaultInjectionPatterns...), cfg.Patterns...) {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid injection pattern %q: %v", ErrInvalidOptions, p.Name, err)
+		}
+		if p.Name == "" {
+			p.Name = p.Pattern
+		}
+		if p.Score <= 0 {
+			p.Score = 60
+		}
+		d.patterns = append(d.patterns, injectionPattern{name: p.Name, re: re, score: p.Score})
+	}
+	return d, nil
+}
+
+// Detect 检测内容是否包含提示词注入，命中多个特征时在最高分的基础上每个加10分
+func (d *InjectionDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
+		return nil, nil
+	}
+
+	// 不使用 NormalizedContent：其中的分隔字符已被去除
+	contents := []string{checkCtx.Content}
+	if folded := foldText(checkCtx.Content); folded != checkCtx.Content {
+		contents = append(contents, folded)
+	}
+
+	var matched []string
+	var score float32
+	for _, p := range d.patterns {
+		for _, content := range contents {
+			if p.re.MatchString(content) {
+				matched = append(matched, p.name)
+				if p.score > score {
+					score = p.score
+				}
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	score += float32(len(matched)-1) * 10
+	if score > 100 {
+		score = 100
+	}
+	sort.Strings(matched)
+
+	return []*model.RiskItem{{
+		Type:        model.RiskTypeSuspiciousBehavior,
+		Score:       score,
+		Description: "内容试图操纵审核模型（提示词注入）",
+		Details: map[string]string{
+			"patterns": strings.Join(matched, ","),
+		},
+	}}, nil
+}
@@ -0,0 +1,230 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// 大语言模型接口类型
+const (
+	LLMProviderOllama = "ollama" // Ollama /api/chat
+	LLMProviderOpenAI = "openai" // OpenAI 及兼容接口（vLLM、llama.cpp server、LM Studio等）的 /chat/completions
+)
+
+// chatMessage 对话消息
+type chatMessage struct {
+	Role    string
+	Content string
+}
+
+// chatClient 大语言模型对话接口
+type chatClient interface {
+	// Chat 发送对话并返回模型回复的文本，schema不为nil时按配置的结构化输出方式约束输出
+	Chat(ctx context.Context, messages []chatMessage, schema *responseSchema) (string, error)
+	// Ping 检查服务是否可用
+	Ping(ctx context.Context) error
+}
+
+// newChatClient 按接口类型创建对话客户端
+func newChatClient(cfg LLMConfig, httpClient *http.Client) (chatClient, error) {
+	switch cfg.Provider {
+	case LLMProviderOllama:
+		return &ollamaClient{cfg: cfg, httpClient: httpClient}, nil
+	case LLMProviderOpenAI:
+		clientCfg := openai.DefaultConfig(cfg.APIKey)
+		clientCfg.BaseURL = cfg.BaseURL
+		clientCfg.HTTPClient = httpClient
+		return &openaiClient{cfg: cfg, client: openai.NewClientWithConfig(clientCfg)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown llm provider %q", ErrInvalidOptions, cfg.Provider)
+	}
+}
+
+// llmBaseURL 从完整的对话接口地址中去掉接口路径，得到服务的基础地址
+func llmBaseURL(endpoint string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	for _, suffix := range []string{"/api/chat", "/chat/completions"} {
+		endpoint = strings.TrimSuffix(endpoint, suffix)
+	}
+	return endpoint
+}
+
+// OllamaChatRequest 结构定义Ollama模型输入
+type OllamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []OllamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   json.RawMessage `json:"format,omitempty"` // "json" 或 JSON Schema
+	Options  OllamaOption    `json:"options,omitempty"`
+}
+
+// OllamaMessage 定义Ollama对话消息
+type OllamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// OllamaOption 提供Ollama请求选项
+type OllamaOption struct {
+	Temperature float32 `json:"temperature"`
+	NumPredict  int     `json:"num_predict,omitempty"` // 最大生成token数
+}
+
+// OllamaChatResponse 定义Ollama模型输出
+type OllamaChatResponse struct {
+	Model     string        `json:"model"`
+	CreatedAt string        `json:"created_at"`
+	Message   OllamaMessage `json:"message"`
+	Done      bool          `json:"done"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// ollamaClient Ollama对话客户端
+type ollamaClient struct {
+	cfg        LLMConfig
+	httpClient *http.Client
+}
+
+// Chat 调用 /api/chat
+func (c *ollamaClient) Chat(ctx context.Context, messages []chatMessage, schema *responseSchema) (string, error) {
+	chatReq := OllamaChatRequest{
+		Model: c.cfg.Model,
+		Options: OllamaOption{
+			Temperature: *c.cfg.Temperature,
+			NumPredict:  c.cfg.MaxTokens,
+		},
+	}
+	if schema != nil {
+		switch c.cfg.StructuredOutput {
+		case StructuredOutputSchema:
+			chatReq.Format = schema.Schema
+		case StructuredOutputJSON:
+			chatReq.Format = json.RawMessage(`"json"`)
+		}
+	}
+	for _, m := range messages {
+		chatReq.Messages = append(chatReq.Messages, OllamaMessage{Role: m.Role, Content: m.Content})
+	}
+
+	reqBody, err := json.Marshal(chatReq)
+	if err != nil {
+		return "", fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+"/api/chat", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("调用模型API失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API返回错误状态码 %d: %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp OllamaChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return "", fmt.Errorf("解析模型响应失败: %w", err)
+	}
+	if chatResp.Error != "" {
+		return "", fmt.Errorf("模型返回错误: %s", chatResp.Error)
+	}
+
+	return chatResp.Message.Content, nil
+}
+
+// Ping 调用 /api/tags
+func (c *ollamaClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+"/api/tags", nil)
+	if err != nil {
+		return fmt.Errorf("创建测试请求失败: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("连接模型服务失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("模型服务返回非200状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// openaiClient OpenAI兼容接口的对话客户端
+type openaiClient struct {
+	cfg    LLMConfig
+	client *openai.Client
+}
+
+// Chat 调用 /chat/completions
+func (c *openaiClient) Chat(ctx context.Context, messages []chatMessage, schema *responseSchema) (string, error) {
+	chatReq := openai.ChatCompletionRequest{
+		Model:       c.cfg.Model,
+		Temperature: *c.cfg.Temperature,
+		MaxTokens:   c.cfg.MaxTokens,
+	}
+	// 温度为0时请求中会省略该字段而使用服务端默认值，以极小值代替
+	if chatReq.Temperature == 0 {
+		chatReq.Temperature = math.SmallestNonzeroFloat32
+	}
+	if schema != nil {
+		switch c.cfg.StructuredOutput {
+		case StructuredOutputSchema:
+			chatReq.ResponseFormat = &openai.ChatCompletionResponseFormat{
+				Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+				JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+					Name:   schema.Name,
+					Schema: schema.Schema,
+					Strict: true,
+				},
+			}
+		case StructuredOutputJSON:
+			chatReq.ResponseFormat = &openai.ChatCompletionResponseFormat{
+				Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+			}
+		}
+	}
+	for _, m := range messages {
+		chatReq.Messages = append(chatReq.Messages, openai.ChatCompletionMessage{Role: m.Role, Content: m.Content})
+	}
+
+	resp, err := c.client.CreateChatCompletion(ctx, chatReq)
+	if err != nil {
+		return "", fmt.Errorf("调用模型API失败: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("模型未返回结果")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+// Ping 调用 /models
+func (c *openaiClient) Ping(ctx context.Context) error {
+	if _, err := c.client.ListModels(ctx); err != nil {
+		return fmt.Errorf("连接模型服务失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// 上下文中的发言人标签，其他用户按出现顺序匿名为 用户A、用户B ...
+const speakerSender = "发送者"
+
+// contextTurn 提示词中的一条上下文消息
+type contextTurn struct {
+	Speaker string `json:"speaker"`
+	Time    string `json:"time,omitempty"` // 相对当前的时间，消息未带时间戳时为空
+	Content string `json:"content"`
+}
+
+// llmConversation 整理后的对话上下文
+type llmConversation struct {
+	Summary string        `json:"summary,omitempty"` // 未逐条列出的更早消息的摘要
+	Turns   []contextTurn `json:"turns"`
+}
+
+// contextBuilder 构建提示词中的对话上下文：保留最近size条消息，按字符预算从最近的消息开始逐条放入，
+// 放不下的及更早的消息只保留摘要
+type contextBuilder struct {
+	size   int
+	budget int // 逐条消息内容的字符数上限
+	now    func() time.Time
+}
+
+// newContextBuilder 创建上下文构建器
+func newContextBuilder(size, budget int) contextBuilder {
+	return contextBuilder{size: size, budget: budget, now: time.Now}
+}
+
+// build 构建对话上下文，没有上下文时返回nil
+func (b contextBuilder) build(checkCtx *model.CheckContext) *llmConversation {
+	items := orderedContext(checkCtx.ContextItems)
+	if len(items) == 0 {
+		return nil
+	}
+
+	speakers := b.speakers(checkCtx.UserID, items)
+	now := b.now()
+
+	recent := items
+	if len(recent) > b.size {
+		recent = recent[len(recent)-b.size:]
+	}
+
+	// 从最近的消息开始放入，最近一条消息超出预算时截断
+	remaining := b.budget
+	var turns []contextTurn
+	for i := len(recent) - 1; i >= 0; i-- {
+		content := []rune(recent[i].Content)
+		if len(content) > remaining {
+			if len(turns) > 0 {
+				break
+			}
+			content = append(content[:remaining], []rune("...")...)
+		}
+		remaining -= len(content)
+		turns = append(turns, contextTurn{
+			Speaker: speakers[recent[i].UserID],
+			Time:    relativeTime(now, recent[i].Timestamp),
+			Content: string(content),
+		})
+	}
+	for i, j := 0, len(turns)-1; i < j; i, j = i+1, j-1 {
+		turns[i], turns[j] = turns[j], turns[i]
+	}
+
+	conversation := &llmConversation{Turns: turns}
+	if older := items[:len(items)-len(turns)]; len(older) > 0 {
+		conversation.Summary = b.summarize(checkCtx.UserID, older, now)
+	}
+	return conversation
+}
+
+// orderedContext 返回按时间排序的上下文消息，部分消息未带时间戳时保持原有顺序
+func orderedContext(items []*model.ContextItem) []*model.ContextItem {
+	ordered := make([]*model.ContextItem, 0, len(items))
+	timed := true
+	for _, item := range items {
+		if item == nil || item.Content == "" {
+			continue
+		}
+		ordered = append(ordered, item)
+		timed = timed && item.Timestamp > 0
+	}
+	if timed {
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].Timestamp < ordered[j].Timestamp
+		})
+	}
+	return ordered
+}
+
+// speakers 为发言人分配标签，避免将用户ID发送给模型
+func (b contextBuilder) speakers(senderID string, items []*model.ContextItem) map[string]string {
+	speakers := map[string]string{senderID: speakerSender}
+	others := 0
+	for _, item := range items {
+		if _, ok := speakers[item.UserID]; ok {
+			continue
+		}
+		speakers[item.UserID] = anonymousSpeaker(others)
+		others++
+	}
+	return speakers
+}
+
+// summarize 生成更早消息的摘要：条数、发言人分布、时间范围及对方是否有拒绝表达
+func (b contextBuilder) summarize(senderID string, items []*model.ContextItem, now time.Time) string {
+	var fromSender, fromOthers int
+	rejected := false
+	for _, item := range items {
+		if item.UserID == senderID {
+			fromSender++
+			continue
+		}
+		fromOthers++
+		rejected = rejected || containsRejection(item.Content)
+	}
+
+	summary := fmt.Sprintf("更早的%d条消息未列出，其中发送者%d条、其他用户%d条", len(items), fromSender, fromOthers)
+	first, last := relativeTime(now, items[0].Timestamp), relativeTime(now, items[len(items)-1].Timestamp)
+	if first != "" && last != "" {
+		summary += fmt.Sprintf("，时间为%s至%s", first, last)
+	}
+	if rejected {
+		summary += "，其他用户曾表达拒绝"
+	}
+	return summary
+}
+
+// anonymousSpeaker 返回第i个其他用户的匿名标签
+func anonymousSpeaker(i int) string {
+	label := string(rune('A' + i%26))
+	if i >= 26 {
+		label += fmt.Sprint(i / 26)
+	}
+	return "用户" + label
+}
+
+// relativeTime 返回时间戳（秒）相对当前的时间描述，时间戳为0时返回空
+func relativeTime(now time.Time, timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	elapsed := now.Sub(time.Unix(timestamp, 0))
+	switch {
+	case elapsed < time.Minute:
+		return "刚刚"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%d分钟前", int(elapsed/time.Minute))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%d小时前", int(elapsed/time.Hour))
+	default:
+		return fmt.Sprintf("%d天前", int(elapsed/(24*time.Hour)))
+	}
+}
@@ -0,0 +1,167 @@
+package detector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+func TestContextBuilder(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	at := func(ago time.Duration) int64 { return now.Add(-ago).Unix() }
+
+	tests := []struct {
+		name        string
+		size        int
+		budget      int
+		items       []*model.ContextItem
+		want        []contextTurn
+		wantSummary string
+	}{
+		{
+			name: "no context",
+			size: 5, budget: 100,
+		},
+		{
+			name: "empty and nil items skipped",
+			size: 5, budget: 100,
+			items: []*model.ContextItem{nil, {UserID: "u2", Content: ""}},
+		},
+		{
+			name: "speakers anonymized in order and sorted by time",
+			size: 5, budget: 100,
+			items: []*model.ContextItem{
+				{UserID: "u3", Content: "第三条", Timestamp: at(30 * time.Second)},
+				{UserID: "u2", Content: "第一条", Timestamp: at(2 * time.Hour)},
+				{UserID: "u1", Content: "第二条", Timestamp: at(5 * time.Minute)},
+			},
+			want: []contextTurn{
+				{Speaker: "用户A", Time: "2小时前", Content: "第一条"},
+				{Speaker: speakerSender, Time: "5分钟前", Content: "第二条"},
+				{Speaker: "用户B", Time: "刚刚", Content: "第三条"},
+			},
+		},
+		{
+			name: "missing timestamps keep original order",
+			size: 5, budget: 100,
+			items: []*model.ContextItem{
+				{UserID: "u2", Content: "后发", Timestamp: at(time.Minute)},
+				{UserID: "u1", Content: "先发"},
+			},
+			want: []contextTurn{
+				{Speaker: "用户A", Time: "1分钟前", Content: "后发"},
+				{Speaker: speakerSender, Content: "先发"},
+			},
+		},
+		{
+			name: "older than size summarized",
+			size: 2, budget: 100,
+			items: []*model.ContextItem{
+				{UserID: "u2", Content: "别再给我发消息了", Timestamp: at(3 * 24 * time.Hour)},
+				{UserID: "u1", Content: "在吗", Timestamp: at(2 * 24 * time.Hour)},
+				{UserID: "u1", Content: "回我", Timestamp: at(time.Hour)},
+				{UserID: "u2", Content: "……", Timestamp: at(10 * time.Minute)},
+			},
+			want: []contextTurn{
+				{Speaker: speakerSender, Time: "1小时前", Content: "回我"},
+				{Speaker: "用户A", Time: "10分钟前", Content: "……"},
+			},
+			wantSummary: "更早的2条消息未列出，其中发送者1条、其他用户1条，时间为3天前至2天前，其他用户曾表达拒绝",
+		},
+		{
+			name: "budget drops older turns",
+			size: 5, budget: 6,
+			items: []*model.ContextItem{
+				{UserID: "u2", Content: "一二三四"},
+				{UserID: "u1", Content: "五六七"},
+				{UserID: "u2", Content: "八九"},
+			},
+			want: []contextTurn{
+				{Speaker: speakerSender, Content: "五六七"},
+				{Speaker: "用户A", Content: "八九"},
+			},
+			wantSummary: "更早的1条消息未列出，其中发送者0条、其他用户1条",
+		},
+		{
+			name: "latest turn truncated to budget",
+			size: 5, budget: 3,
+			items: []*model.ContextItem{
+				{UserID: "u2", Content: "之前"},
+				{UserID: "u2", Content: "一二三四五"},
+			},
+			want: []contextTurn{
+				{Speaker: "用户A", Content: "一二三..."},
+			},
+			wantSummary: "更早的1条消息未列出，其中发送者0条、其他用户1条",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newContextBuilder(tt.size, tt.budget)
+			b.now = func() time.Time { return now }
+
+			conversation := b.build(&model.CheckContext{Content: "当前消息", UserID: "u1", ContextItems: tt.items})
+			if tt.want == nil {
+				if conversation != nil {
+					t.Errorf("build = %+v, want nil", conversation)
+				}
+				return
+			}
+			if conversation == nil {
+				t.Fatal("build = nil")
+			}
+			if !reflect.DeepEqual(conversation.Turns, tt.want) {
+				t.Errorf("turns = %+v, want %+v", conversation.Turns, tt.want)
+			}
+			if conversation.Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", conversation.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAnonymousSpeaker(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{0, "用户A"},
+		{25, "用户Z"},
+		{26, "用户A1"},
+		{53, "用户B2"},
+	}
+	for _, tt := range tests {
+		if got := anonymousSpeaker(tt.i); got != tt.want {
+			t.Errorf("anonymousSpeaker(%d) = %q, want %q", tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "刚刚"},
+		{59 * time.Second, "刚刚"},
+		{time.Minute, "1分钟前"},
+		{59 * time.Minute, "59分钟前"},
+		{3 * time.Hour, "3小时前"},
+		{49 * time.Hour, "2天前"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now, now.Add(-tt.ago).Unix()); got != tt.want {
+			t.Errorf("relativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := relativeTime(now, 0); got != "" {
+		t.Errorf("relativeTime(0) = %q, want empty", got)
+	}
+	if !strings.HasSuffix(relativeTime(now, 1), "天前") {
+		t.Error("relativeTime of an old timestamp should be in days")
+	}
+}
@@ -0,0 +1,565 @@
+package detector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+)
+
+// defaultLLMSystemPrompt 默认系统提示词模板，可使用 {{.Categories}}（分类类别）及 {{.Scene}}（业务场景）
+const defaultLLMSystemPrompt = `你是一个内容安全分析专家，你需要分析用户输入的文本是否包含不安全内容，如侮辱、骚扰、威胁、仇恨言论等。
+请分析用户提供的文本，并返回JSON格式的分析结果，包括：
+- 是否有害（is_toxic）: true或false
+- 分类（categories）: 可能的类别包括{{.Categories}}，为每个相关类别提供0-1之间的分数
+- 解释（explanation）: 简要解释判断理由
+- 意图（intent）: "harmful", "neutral", "friendly"中的一个
+- 情感（sentiment）: "negative", "neutral", "positive"中的一个
+- 风险分数（risk_score）: 0-1之间的总体风险分数
+
+必须严格按照JSON格式输出，不要输出任何其他内容！`
+
+// defaultLLMCategories 默认分类类别及对应的风险类型
+var defaultLLMCategories = []struct {
+	Name     string
+	RiskType model.RiskType
+}{
+	{"insult", model.RiskTypeHarassment},
+	{"threat", model.RiskTypeViolence},
+	{"harassment", model.RiskTypeHarassment},
+	{"hate_speech", model.RiskTypeHateSpeech},
+	{"self_harm", model.RiskTypeSelfHarm},
+	{"sexual", model.RiskTypeAdult},
+	{"violence", model.RiskTypeViolence},
+}
+
+// LLMCategoryConfig 分类类别配置
+type LLMCategoryConfig struct {
+	RiskType  string  `mapstructure:"risk_type"` // 对应的风险类型，如 harassment、violence、adult，新增类别时必填
+	Threshold float32 `mapstructure:"threshold"` // 类别分数（0-1）超过该值时输出风险项，默认使用检测器的 threshold
+}
+
+// llmCategory 分类类别
+type llmCategory struct {
+	name      string
+	riskType  model.RiskType
+	threshold float32
+}
+
+// errBreakerOpen 熔断期间不调用模型
+var errBreakerOpen = errors.New("llm circuit breaker open")
+
+// LLMConfig 大语言模型检测器配置
+type LLMConfig struct {
+	Provider         string        `mapstructure:"provider"`          // ollama 或 openai（OpenAI兼容接口）
+	BaseURL          string        `mapstructure:"base_url"`          // 服务地址，ollama 默认 http://localhost:11434，openai 默认 https://api.openai.com/v1
+	Model            string        `mapstructure:"model"`             // 模型名称
+	APIKey           string        `mapstructure:"api_key"`           // API密钥，本地服务可不配置
+	Temperature      *float32      `mapstructure:"temperature"`       // 默认0.1
+	MaxTokens        int           `mapstructure:"max_tokens"`        // 最大生成token数，默认512
+	SystemPrompt     string        `mapstructure:"system_prompt"`     // 系统提示词模板，为空时使用默认模板，防注入说明始终追加在其后
+	StructuredOutput string        `mapstructure:"structured_output"` // 结构化输出方式: schema（默认）, json, off，服务不支持JSON Schema时可改为json
+	Threshold        float32       `mapstructure:"threshold"`         // 风险分数（0-1）超过该值时输出风险项，默认0.5
+	ContextSize      int           `mapstructure:"context_size"`      // 提示词中逐条列出的最近上下文消息数，默认5
+	ContextBudget    int           `mapstructure:"context_budget"`    // 逐条列出的上下文内容的字符数上限，超出部分只保留摘要，默认2000
+	Timeout          int           `mapstructure:"timeout"`           // 单次请求超时（毫秒），默认30000
+	Breaker          BreakerConfig `mapstructure:"breaker"`
+	// Canary 要求模型回显每次请求随机生成的校验值，不一致时视为输出被待审核内容操纵，默认true；
+	// json、off 模式下未回显不视为不一致
+	Canary *bool `mapstructure:"canary"`
+	// Categories 按类别名覆盖默认类别的风险类型、阈值，或新增类别
+	Categories map[string]LLMCategoryConfig `mapstructure:"categories"`
+}
+
+// withDefaults 返回补全默认值后的配置
+func (c LLMConfig) withDefaults() LLMConfig {
+	if c.Provider == "" {
+		c.Provider = LLMProviderOllama
+	}
+	if c.BaseURL == "" {
+		switch c.Provider {
+		case LLMProviderOllama:
+			c.BaseURL = "http://localhost:11434"
+		case LLMProviderOpenAI:
+			c.BaseURL = "https://api.openai.com/v1"
+		}
+	}
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/")
+	if c.Model == "" {
+		switch c.Provider {
+		case LLMProviderOllama:
+			c.Model = "llama3"
+		case LLMProviderOpenAI:
+			c.Model = "gpt-3.5-turbo"
+		}
+	}
+	if c.Temperature == nil {
+		temperature := float32(0.1)
+		c.Temperature = &temperature
+	}
+	if c.MaxTokens <= 0 {
+		c.MaxTokens = 512
+	}
+	if c.SystemPrompt == "" {
+		c.SystemPrompt = defaultLLMSystemPrompt
+	}
+	if c.StructuredOutput == "" {
+		c.StructuredOutput = StructuredOutputSchema
+	}
+	if c.Threshold <= 0 {
+		c.Threshold = 0.5
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 30000
+	}
+	if c.ContextSize <= 0 {
+		c.ContextSize = 5
+	}
+	if c.ContextBudget <= 0 {
+		c.ContextBudget = 2000
+	}
+	if c.Canary == nil {
+		canary := true
+		c.Canary = &canary
+	}
+	return c
+}
+
+// llmPromptData 系统提示词模板参数
+type llmPromptData struct {
+	Categories string
+	Scene      string
+}
+
+// SemanticAnalysisResult 语义分析结果结构
+type SemanticAnalysisResult struct {
+	IsToxic     bool               `json:"is_toxic"`
+	Categories  map[string]float32 `json:"categories"`
+	Explanation string             `json:"explanation"`
+	Intent      string             `json:"intent"`
+	Sentiment   string             `json:"sentiment"`
+	Risk        float32            `json:"risk_score"`
+	Canary      string             `json:"canary"`
+}
+
+// LLMDetector 基于大语言模型的语义检测器，支持Ollama及OpenAI兼容接口
+type LLMDetector struct {
+	cfg          LLMConfig
+	client       chatClient
+	systemPrompt *template.Template
+	categories   []llmCategory
+	names        []string        // 类别名，用于提示词、输出约束及解析
+	schema       *responseSchema // 输出约束，未启用结构化输出时为nil
+	contexts     contextBuilder
+	breaker      *CircuitBreaker // 熔断器，熔断期间使用关键词降级检测
+}
+
+// legacyLLMOptions nlp、semantic_nlp 类型的配置，在 LLMConfig 的基础上兼容完整的接口地址 endpoint
+type legacyLLMOptions struct {
+	LLMConfig `mapstructure:",squash"`
+	Endpoint  string `mapstructure:"endpoint"`
+}
+
+func init() {
+	Register("llm", func(opts Options, _ Dependencies) (Detector, error) {
+		var cfg LLMConfig
+		if err := opts.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("llm detector: %w", err)
+		}
+		return NewLLMDetector(cfg)
+	})
+
+	// nlp（默认OpenAI）及 semantic_nlp（默认Ollama）为 llm 的兼容类型
+	for typ, provider := range map[string]string{"nlp": LLMProviderOpenAI, "semantic_nlp": LLMProviderOllama} {
+		typ, provider := typ, provider
+		Register(typ, func(opts Options, _ Dependencies) (Detector, error) {
+			var o legacyLLMOptions
+			if err := opts.Decode(&o); err != nil {
+				return nil, fmt.Errorf("%s detector: %w", typ, err)
+			}
+			cfg := o.LLMConfig
+			if cfg.Provider == "" {
+				cfg.Provider = provider
+			}
+			if cfg.BaseURL == "" && o.Endpoint != "" {
+				cfg.BaseURL = llmBaseURL(o.Endpoint)
+			}
+			return NewLLMDetector(cfg)
+		})
+	}
+}
+
+// NewLLMDetector 创建大语言模型检测器，服务连接测试失败时熔断器直接打开，冷却后由探测请求自动恢复
+func NewLLMDetector(cfg LLMConfig) (*LLMDetector, error) {
+	cfg = cfg.withDefaults()
+
+	switch cfg.StructuredOutput {
+	case StructuredOutputSchema, StructuredOutputJSON, StructuredOutputOff:
+	default:
+		return nil, fmt.Errorf("%w: unknown structured output %q", ErrInvalidOptions, cfg.StructuredOutput)
+	}
+
+	systemPrompt, err := template.New("system_prompt").Parse(cfg.SystemPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid system prompt template: %v", ErrInvalidOptions, err)
+	}
+
+	transport := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    30 * time.Second,
+		DisableCompression: true,
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Duration(cfg.Timeout) * time.Millisecond}
+
+	categories, err := buildLLMCategories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newChatClient(cfg, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	detector := &LLMDetector{
+		cfg:          cfg,
+		client:       client,
+		systemPrompt: systemPrompt,
+		categories:   categories,
+		contexts:     newContextBuilder(cfg.ContextSize, cfg.ContextBudget),
+		breaker:      NewCircuitBreaker(cfg.Breaker),
+	}
+	for _, c := range categories {
+		detector.names = append(detector.names, c.name)
+	}
+	if cfg.StructuredOutput != StructuredOutputOff {
+		detector.schema = verdictSchema(detector.names, *cfg.Canary)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx); err != nil {
+		detector.breaker.Trip()
+	}
+
+	return detector, nil
+}
+
+// buildLLMCategories 在默认类别的基础上应用类别配置，新增类别按名称排序追加在默认类别之后
+func buildLLMCategories(cfg LLMConfig) ([]llmCategory, error) {
+	categories := make([]llmCategory, 0, len(defaultLLMCategories)+len(cfg.Categories))
+	index := make(map[string]int, len(defaultLLMCategories))
+	for _, c := range defaultLLMCategories {
+		index[c.Name] = len(categories)
+		categories = append(categories, llmCategory{name: c.Name, riskType: c.RiskType, threshold: cfg.Threshold})
+	}
+
+	names := make([]string, 0, len(cfg.Categories))
+	for name := range cfg.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c := cfg.Categories[name]
+		category := llmCategory{name: normalizeCategory(name), threshold: cfg.Threshold}
+		if category.name == "" {
+			return nil, fmt.Errorf("%w: empty llm category name", ErrInvalidOptions)
+		}
+		i, exists := index[category.name]
+		if exists {
+			category.riskType = categories[i].riskType
+		}
+		if c.RiskType != "" {
+			riskType, ok := model.ParseRiskType(c.RiskType)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown risk type %q for llm category %q", ErrInvalidOptions, c.RiskType, name)
+			}
+			category.riskType = riskType
+		} else if !exists {
+			return nil, fmt.Errorf("%w: risk_type is required for llm category %q", ErrInvalidOptions, name)
+		}
+		if c.Threshold > 0 {
+			category.threshold = c.Threshold
+		}
+
+		if exists {
+			categories[i] = category
+		} else {
+			index[category.name] = len(categories)
+			categories = append(categories, category)
+		}
+	}
+
+	return categories, nil
+}
+
+// CircuitBreaker 返回检测器的熔断器
+func (d *LLMDetector) CircuitBreaker() *CircuitBreaker {
+	return d.breaker
+}
+
+// Detect 执行语义检测
+func (d *LLMDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
+		return nil, nil
+	}
+
+	result, err := d.verdict(ctx, checkCtx)
+	switch {
+	case errors.Is(err, errBreakerOpen):
+		// 熔断期间使用基本的规则检测
+		return d.fallbackDetect(checkCtx)
+	case errors.Is(err, errCanaryMismatch):
+		return []*model.RiskItem{canaryMismatchRisk()}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	// 将分析结果转换为风险项
+	risks := verdictRisks(result, d.categories, d.cfg.Threshold)
+
+	// 检查上下文模式
+	if hasContextualRisk(checkCtx) {
+		contextRisk := model.NewRiskItem(
+			model.RiskTypeContextViolation,
+			result.Risk*90,
+			"检测到上下文相关的风险行为",
+		)
+		risks = append(risks, contextRisk)
+	}
+
+	return risks, nil
+}
+
+// verdict 调用模型获取分析结果并记录熔断统计，熔断期间返回errBreakerOpen
+func (d *LLMDetector) verdict(ctx context.Context, checkCtx *model.CheckContext) (*SemanticAnalysisResult, error) {
+	if !d.breaker.Allow() {
+		return nil, errBreakerOpen
+	}
+
+	result, err := d.analyzeContent(ctx, checkCtx.Content, checkCtx.Scene, d.contexts.build(checkCtx))
+	switch {
+	case err == nil:
+		d.breaker.Success()
+		return result, nil
+	case ctx.Err() != nil:
+		// 超时或调用方取消不代表服务不可用，不计入熔断统计
+		d.breaker.Release()
+		return nil, ctx.Err()
+	case errors.Is(err, errCanaryMismatch):
+		// 输出未按要求回显校验值，可能被待审核内容操纵，不采信该结果
+		d.breaker.Success()
+		llmCanaryMismatches.Add(d.cfg.Model, 1)
+	case errors.Is(err, errUnparseableVerdict):
+		// 服务可用但输出无法解析
+		d.breaker.Success()
+	default:
+		d.breaker.Failure()
+	}
+	return nil, err
+}
+
+// canaryMismatchRisk 校验值不一致时输出的风险项
+func canaryMismatchRisk() *model.RiskItem {
+	return model.NewRiskItem(
+		model.RiskTypeSuspiciousBehavior,
+		canaryMismatchScore,
+		"模型输出未通过校验，内容可能试图操纵审核模型",
+	)
+}
+
+// verdictRisks 将分析结果转换为风险项：分数超过阈值的类别按风险类型输出，
+// 总体判定有害但没有类别超过阈值时，按最高分类别的风险类型输出
+func verdictRisks(result *SemanticAnalysisResult, categories []llmCategory, threshold float32) []*model.RiskItem {
+	risks := categoryRisks(result, categories)
+	if len(risks) == 0 && result.IsToxic && result.Risk > threshold {
+		riskType := model.RiskTypeUnknown
+		var top float32
+		for _, c := range categories {
+			if score, ok := result.Categories[c.name]; ok && score > top {
+				riskType, top = c.riskType, score
+			}
+		}
+		riskItem := model.NewRiskItem(riskType, result.Risk*100, result.Explanation)
+		if result.Intent != "" {
+			riskItem.Details["intent"] = result.Intent
+		}
+		risks = append(risks, riskItem)
+	}
+	return risks
+}
+
+// analyzeContent 使用大语言模型分析内容，待分析内容转义后放入随机标签内，与指令隔离
+func (d *LLMDetector) analyzeContent(ctx context.Context, content, scene string, conversation *llmConversation) (*SemanticAnalysisResult, error) {
+	prompt := newLLMPrompt()
+
+	var systemPrompt strings.Builder
+	err := d.systemPrompt.Execute(&systemPrompt, llmPromptData{
+		Categories: `"` + strings.Join(d.names, `", "`) + `"`,
+		Scene:      scene,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("生成系统提示词失败: %w", err)
+	}
+	systemPrompt.WriteString(prompt.guard(*d.cfg.Canary))
+
+	messages := []chatMessage{
+		{Role: "system", Content: systemPrompt.String()},
+		{Role: "user", Content: prompt.userMessage(content, conversation)},
+	}
+	reply, err := d.client.Chat(ctx, messages, d.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result, parseErr := parseVerdict(reply, d.names)
+	if parseErr == nil {
+		return d.checkCanary(result, prompt)
+	}
+
+	// 输出无法解析时带上原输出重试一次
+	llmRepairAttempts.Add(d.cfg.Model, 1)
+	messages = append(messages,
+		chatMessage{Role: "assistant", Content: reply},
+		chatMessage{Role: "user", Content: llmRepairPrompt},
+	)
+	reply, err = d.client.Chat(ctx, messages, d.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result, parseErr = parseVerdict(reply, d.names)
+	if parseErr != nil {
+		llmUnparseableResponses.Add(d.cfg.Model, 1)
+		return nil, parseErr
+	}
+	return d.checkCanary(result, prompt)
+}
+
+// checkCanary 校验模型输出中回显的校验值。json、off 模式下输出不受Schema约束，模型遗漏校验值较常见，
+// 仅回显了不一致的校验值时视为输出被操纵，未回显时只计数
+func (d *LLMDetector) checkCanary(result *SemanticAnalysisResult, prompt llmPrompt) (*SemanticAnalysisResult, error) {
+	if !*d.cfg.Canary {
+		return result, nil
+	}
+	canary := strings.TrimSpace(result.Canary)
+	if canary == "" && d.cfg.StructuredOutput != StructuredOutputSchema {
+		llmCanaryMissing.Add(d.cfg.Model, 1)
+		return result, nil
+	}
+	if canary != prompt.canary {
+		return nil, fmt.Errorf("%w: got %q", errCanaryMismatch, truncateReply(result.Canary))
+	}
+	return result, nil
+}
+
+// fallbackDetect 熔断期间的降级检测
+func (d *LLMDetector) fallbackDetect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
+	// 使用简单的关键词匹配和规则
+	content := ctx.Content
+	var risks []*model.RiskItem
+
+	// 定义简单的有害词列表
+	harmfulWords := []string{
+		"傻逼", "混蛋", "垃圾", "白痴", "废物", "贱人",
+		"去死", "杀了你", "打死你", "灭了你", "弄死你",
+	}
+
+	// 检查敏感词
+	for _, word := range harmfulWords {
+		if strings.Contains(content, word) {
+			risks = append(risks, model.NewRiskItem(
+				model.RiskTypeHarassment,
+				80.0,
+				fmt.Sprintf("检测到敏感词: %s", word),
+			))
+			break
+		}
+	}
+
+	// 检查威胁模式
+	threatPatterns := []string{
+		"小心", "当心", "威胁", "后果", "找你", "报复",
+	}
+	for _, pattern := range threatPatterns {
+		if strings.Contains(content, pattern) {
+			risks = append(risks, model.NewRiskItem(
+				model.RiskTypeHarassment,
+				75.0,
+				"检测到潜在威胁性语言",
+			))
+			break
+		}
+	}
+
+	// 检查是否有上下文拒绝后继续骚扰的情况
+	if hasContextualRisk(ctx) {
+		risks = append(risks, model.NewRiskItem(
+			model.RiskTypeContextViolation,
+			70.0,
+			"检测到可能在对方拒绝后继续发送消息",
+		))
+	}
+
+	return risks, nil
+}
+
+// rejectionPhrases 拒绝表达，单独的“别”“不要”在日常对话中过于常见，不作为拒绝表达
+var rejectionPhrases = []string{
+	"别再", "别来", "别发", "别烦", "别找我", "别骚扰",
+	"不要再", "不要发", "不要找我", "不想理你", "不想聊",
+	"停止", "拒绝", "讨厌你", "烦人", "骚扰",
+}
+
+// containsRejection 检查内容是否包含拒绝表达
+func containsRejection(text string) bool {
+	for _, phrase := range rejectionPhrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasContextualRisk 检查其他用户是否在上下文中表达过拒绝，即发送者可能在对方拒绝后继续发送消息
+func hasContextualRisk(checkCtx *model.CheckContext) bool {
+	for _, item := range checkCtx.ContextItems {
+		if item != nil && item.UserID != checkCtx.UserID && containsRejection(item.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryRisks 为分数超过阈值的类别输出风险项，同一风险类型的多个类别合并为一项，分数取最高值
+func categoryRisks(result *SemanticAnalysisResult, categories []llmCategory) []*model.RiskItem {
+	var risks []*model.RiskItem
+	byType := make(map[model.RiskType]*model.RiskItem)
+	for _, c := range categories {
+		score, ok := result.Categories[c.name]
+		if !ok || score <= c.threshold {
+			continue
+		}
+
+		riskItem, exists := byType[c.riskType]
+		if !exists {
+			riskItem = model.NewRiskItem(c.riskType, score*100, result.Explanation)
+			if result.Intent != "" {
+				riskItem.Details["intent"] = result.Intent
+			}
+			byType[c.riskType] = riskItem
+			risks = append(risks, riskItem)
+		} else if score*100 > riskItem.Score {
+			riskItem.Score = score * 100
+		}
+		riskItem.Details[c.name] = fmt.Sprintf("%.2f", score)
+	}
+	return risks
+}
@@ -0,0 +1,268 @@
+package detector
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 结构化输出方式
+const (
+	StructuredOutputSchema = "schema" // 按JSON Schema约束输出（Ollama format、OpenAI response_format json_schema）
+	StructuredOutputJSON   = "json"   // 仅要求输出JSON（Ollama format "json"、OpenAI response_format json_object）
+	StructuredOutputOff    = "off"    // 不约束，仅依赖提示词
+)
+
+// llmRepairPrompt 输出无法解析时的修复提示
+const llmRepairPrompt = "上面的输出不是符合要求的JSON。请按系统提示的字段要求只输出一个JSON对象，分数均为0-1之间的数值，不要输出任何其他内容。"
+
+// maxLoggedReplyLen 错误信息中保留的模型输出长度
+const maxLoggedReplyLen = 200
+
+var (
+	// llmUnparseableResponses 修复重试后仍无法解析的模型输出数，按模型计数，通过 /debug/vars 暴露
+	llmUnparseableResponses = expvar.NewMap("llm_unparseable_responses")
+	// llmRepairAttempts 因输出无法解析而发起的修复重试数，按模型计数
+	llmRepairAttempts = expvar.NewMap("llm_repair_attempts")
+)
+
+// errUnparseableVerdict 模型输出无法解析为分析结果
+var errUnparseableVerdict = errors.New("unparseable llm verdict")
+
+var (
+	llmIntents    = []string{"harmful", "neutral", "friendly"}
+	llmSentiments = []string{"negative", "neutral", "positive"}
+)
+
+// responseSchema 约束模型输出的JSON Schema
+type responseSchema struct {
+	Name   string
+	Schema json.RawMessage
+}
+
+// verdictSchema 生成分析结果的JSON Schema，所有字段必填且不允许额外字段，以满足OpenAI strict模式，canary为true时要求返回校验值
+func verdictSchema(categories []string, canary bool) *responseSchema {
+	categoryProps := make(map[string]interface{}, len(categories))
+	for _, category := range categories {
+		categoryProps[category] = map[string]string{"type": "number"}
+	}
+
+	properties := map[string]interface{}{
+		"is_toxic": map[string]string{"type": "boolean"},
+		"categories": map[string]interface{}{
+			"type":                 "object",
+			"properties":           categoryProps,
+			"required":             categories,
+			"additionalProperties": false,
+		},
+		"explanation": map[string]string{"type": "string"},
+		"intent":      map[string]interface{}{"type": "string", "enum": llmIntents},
+		"sentiment":   map[string]interface{}{"type": "string", "enum": llmSentiments},
+		"risk_score":  map[string]string{"type": "number"},
+	}
+	required := []string{"is_toxic", "categories", "explanation", "intent", "sentiment", "risk_score"}
+	if canary {
+		properties["canary"] = map[string]string{"type": "string"}
+		required = append(required, "canary")
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+
+	data, _ := json.Marshal(schema)
+	return &responseSchema{Name: "content_safety_verdict", Schema: data}
+}
+
+// parseVerdict 从模型输出中提取分析结果并校验、归一化，categories为允许的分类类别
+func parseVerdict(reply string, categories []string) (*SemanticAnalysisResult, error) {
+	raw, ok := extractJSONObject(reply)
+	if !ok {
+		return nil, fmt.Errorf("%w: no JSON object in reply: %s", errUnparseableVerdict, truncateReply(reply))
+	}
+
+	result := &SemanticAnalysisResult{Categories: make(map[string]float32)}
+
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		known[category] = true
+	}
+	switch v := raw["categories"].(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			category := normalizeCategory(name)
+			score, ok := normalizeScore(value)
+			if known[category] && ok {
+				result.Categories[category] = score
+			}
+		}
+	case []interface{}:
+		// 仅列出类别名时，以总体风险分数作为类别分数
+		score, _ := normalizeScore(raw["risk_score"])
+		for _, name := range v {
+			if s, ok := name.(string); ok && known[normalizeCategory(s)] {
+				result.Categories[normalizeCategory(s)] = score
+			}
+		}
+	}
+
+	risk, hasRisk := normalizeScore(raw["risk_score"])
+	if _, present := raw["risk_score"]; present && !hasRisk {
+		return nil, fmt.Errorf("%w: risk_score out of range: %v", errUnparseableVerdict, raw["risk_score"])
+	}
+	if !hasRisk {
+		// 未给出总体风险分数时取类别最高分
+		for _, score := range result.Categories {
+			if score > risk {
+				risk = score
+			}
+		}
+	}
+	result.Risk = risk
+
+	toxic, hasToxic := parseBool(raw["is_toxic"])
+	if !hasToxic && !hasRisk && len(result.Categories) == 0 {
+		return nil, fmt.Errorf("%w: missing is_toxic and risk_score: %s", errUnparseableVerdict, truncateReply(reply))
+	}
+	if !hasToxic {
+		toxic = risk >= 0.5
+	}
+	result.IsToxic = toxic
+
+	result.Explanation, _ = raw["explanation"].(string)
+	result.Intent = normalizeEnum(raw["intent"], llmIntents)
+	result.Sentiment = normalizeEnum(raw["sentiment"], llmSentiments)
+	result.Canary, _ = raw["canary"].(string)
+
+	return result, nil
+}
+
+// extractJSONObject 从模型输出中提取第一个可解析的JSON对象，兼容代码块包裹及前后附带说明文字的输出
+func extractJSONObject(reply string) (map[string]interface{}, bool) {
+	var obj map[string]interface{}
+	trimmed := strings.TrimSpace(reply)
+	if json.Unmarshal([]byte(trimmed), &obj) == nil && obj != nil {
+		return obj, true
+	}
+
+	for start := strings.IndexByte(trimmed, '{'); start >= 0; {
+		if end := matchingBrace(trimmed, start); end > start {
+			obj = nil
+			if json.Unmarshal([]byte(trimmed[start:end+1]), &obj) == nil && obj != nil {
+				return obj, true
+			}
+		}
+		next := strings.IndexByte(trimmed[start+1:], '{')
+		if next < 0 {
+			break
+		}
+		start += next + 1
+	}
+
+	return nil, false
+}
+
+// matchingBrace 返回与start处左花括号匹配的右花括号位置，忽略字符串中的花括号，未找到时返回-1
+func matchingBrace(s string, start int) int {
+	depth := 0
+	inString, escaped := false, false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// normalizeScore 将分数归一化到[0, 1]，兼容字符串、百分号及0-100的分数，超出范围时返回false。
+// (1, 2) 之间的分数无法区分是0-1的分数越界还是0-100的低分，同样视为超出范围
+func normalizeScore(value interface{}) (float32, bool) {
+	var score float64
+	switch v := value.(type) {
+	case float64:
+		score = v
+	case string:
+		s := strings.TrimSpace(v)
+		f, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil {
+			return 0, false
+		}
+		if strings.HasSuffix(s, "%") {
+			f /= 100
+			if f < 0 || f > 1 {
+				return 0, false
+			}
+			return float32(f), true
+		}
+		score = f
+	default:
+		return 0, false
+	}
+
+	if score >= 2 && score <= 100 {
+		score /= 100
+	}
+	if score < 0 || score > 1 {
+		return 0, false
+	}
+	return float32(score), true
+}
+
+// normalizeCategory 统一类别名的大小写及分隔符，如 "Hate Speech" -> "hate_speech"
+func normalizeCategory(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// normalizeEnum 返回取值在allowed中的小写字符串，否则返回空
+func normalizeEnum(value interface{}, allowed []string) string {
+	s, _ := value.(string)
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, a := range allowed {
+		if s == a {
+			return s
+		}
+	}
+	return ""
+}
+
+// parseBool 解析布尔值，兼容字符串形式
+func parseBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// truncateReply 截断模型输出用于错误信息
+func truncateReply(reply string) string {
+	runes := []rune(reply)
+	if len(runes) > maxLoggedReplyLen {
+		return string(runes[:maxLoggedReplyLen]) + "..."
+	}
+	return reply
+}
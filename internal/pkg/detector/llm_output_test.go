@@ -0,0 +1,129 @@
+package detector
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		want   float32
+		wantOK bool
+	}{
+		{0.0, 0, true},
+		{0.85, 0.85, true},
+		{1.0, 1, true},
+		{85.0, 0.85, true},
+		{100.0, 1, true},
+		{2.0, 0.02, true},
+		// 0-1分数越界还是0-100的低分无法区分
+		{1.5, 0, false},
+		{-0.1, 0, false},
+		{120.0, 0, false},
+		{"0.7", 0.7, true},
+		{" 70 ", 0.7, true},
+		{"70%", 0.7, true},
+		{"1.5%", 0.015, true},
+		{"150%", 0, false},
+		{"1.5", 0, false},
+		{"high", 0, false},
+		{true, 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeScore(tt.value)
+		if ok != tt.wantOK || math.Abs(float64(got-tt.want)) > 1e-6 {
+			t.Errorf("normalizeScore(%#v) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	categories := []string{"insult", "threat", "hate_speech"}
+	tests := []struct {
+		name    string
+		reply   string
+		want    *SemanticAnalysisResult
+		wantErr bool
+	}{
+		{
+			name:  "strict output",
+			reply: `{"is_toxic":true,"categories":{"insult":0.2,"threat":0.9,"hate_speech":0},"explanation":"威胁","intent":"harmful","sentiment":"negative","risk_score":0.9,"canary":"abcd"}`,
+			want: &SemanticAnalysisResult{
+				IsToxic:     true,
+				Categories:  map[string]float32{"insult": 0.2, "threat": 0.9, "hate_speech": 0},
+				Explanation: "威胁",
+				Intent:      "harmful",
+				Sentiment:   "negative",
+				Risk:        0.9,
+				Canary:      "abcd",
+			},
+		},
+		{
+			name:  "code fence, percent scale and loose field values",
+			reply: "分析结果如下：\n```json\n{\"is_toxic\":\"true\",\"categories\":{\"Hate Speech\":80,\"unknown\":0.9},\"intent\":\"Harmful\",\"sentiment\":\"angry\",\"risk_score\":\"80%\"}\n```",
+			want: &SemanticAnalysisResult{
+				IsToxic:    true,
+				Categories: map[string]float32{"hate_speech": 0.8},
+				Intent:     "harmful",
+				Risk:       0.8,
+			},
+		},
+		{
+			name:  "category list uses risk score",
+			reply: `{"categories":["insult","Threat"],"risk_score":0.6}`,
+			want: &SemanticAnalysisResult{
+				IsToxic:    true,
+				Categories: map[string]float32{"insult": 0.6, "threat": 0.6},
+				Risk:       0.6,
+			},
+		},
+		{
+			name:  "risk from highest category",
+			reply: `{"categories":{"insult":0.3,"threat":0.4}}`,
+			want: &SemanticAnalysisResult{
+				Categories: map[string]float32{"insult": 0.3, "threat": 0.4},
+				Risk:       0.4,
+			},
+		},
+		{
+			name:  "ambiguous category score dropped",
+			reply: `{"is_toxic":false,"categories":{"insult":1.5},"risk_score":0.1}`,
+			want: &SemanticAnalysisResult{
+				Categories: map[string]float32{},
+				Risk:       0.1,
+			},
+		},
+		{
+			name:  "braces inside strings",
+			reply: `说明{不是JSON} {"explanation":"含有}的说明","is_toxic":false,"risk_score":0}`,
+			want: &SemanticAnalysisResult{
+				Categories:  map[string]float32{},
+				Explanation: "含有}的说明",
+			},
+		},
+		{name: "no JSON", reply: "这段内容是安全的", wantErr: true},
+		{name: "risk score out of range", reply: `{"is_toxic":true,"risk_score":1.5}`, wantErr: true},
+		{name: "missing verdict", reply: `{"explanation":"无法判断"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVerdict(tt.reply, categories)
+			if tt.wantErr {
+				if !errors.Is(err, errUnparseableVerdict) {
+					t.Fatalf("parseVerdict error = %v, want errUnparseableVerdict", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseVerdict: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseVerdict = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
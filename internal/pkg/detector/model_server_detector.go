@@ -0,0 +1,273 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/classifier"
+)
+
+// ModelAnalyzer 进程内的NLP模型服务，模型服务与审核服务运行在同一进程时由 Dependencies 传入
+type ModelAnalyzer interface {
+	Analyze(req classifier.AnalyzeRequest) (*classifier.Analysis, error)
+}
+
+// modelServerAnalysisTypes 请求的分析类型
+var modelServerAnalysisTypes = []string{
+	classifier.TaskIntent,
+	classifier.TaskSentiment,
+	classifier.TaskToxicity,
+	classifier.AnalysisSimilarity,
+}
+
+// modelServerRiskTypes 有害类别及意图标签对应的风险类型，未列出的有害类别为 unknown，未列出的意图不输出风险
+var modelServerRiskTypes = map[string]model.RiskType{
+	"insult":      model.RiskTypeHarassment,
+	"harassment":  model.RiskTypeHarassment,
+	"threat":      model.RiskTypeViolence,
+	"violence":    model.RiskTypeViolence,
+	"hate":        model.RiskTypeHateSpeech,
+	"hate_speech": model.RiskTypeHateSpeech,
+	"sexual":      model.RiskTypeAdult,
+	"adult":       model.RiskTypeAdult,
+	"self_harm":   model.RiskTypeSelfHarm,
+	"spam":        model.RiskTypeSpam,
+}
+
+// ModelServerConfig NLP模型服务检测器配置
+type ModelServerConfig struct {
+	URL                 string  `mapstructure:"url"`                  // 模型服务地址，如 http://localhost:8010；为空时调用进程内的模型服务
+	Threshold           float32 `mapstructure:"threshold"`            // 有害内容概率、风险意图及负面情感强度（0-1）的判定阈值，默认0.6
+	SimilarityThreshold float32 `mapstructure:"similarity_threshold"` // 与发送者近期消息的相似度（0-1）达到该值时视为重复发送，默认0.8
+	ContextSize         int     `mapstructure:"context_size"`         // 参与相似度分析的发送者近期消息数，默认5
+	Timeout             int     `mapstructure:"timeout"`              // 请求超时（毫秒），默认2000
+}
+
+// withDefaults 返回补全默认值后的配置
+func (c ModelServerConfig) withDefaults() ModelServerConfig {
+	if c.Threshold <= 0 {
+		c.Threshold = 0.6
+	}
+	if c.SimilarityThreshold <= 0 {
+		c.SimilarityThreshold = 0.8
+	}
+	if c.ContextSize <= 0 {
+		c.ContextSize = 5
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 2000
+	}
+	return c
+}
+
+// ModelServerDetector NLP模型服务检测器，调用模型服务的 /analyze 进行意图、情感、有害内容及相似度分析并转换为风险项
+type ModelServerDetector struct {
+	cfg      ModelServerConfig
+	analyzer ModelAnalyzer // 进程内的模型服务，为nil时通过HTTP调用
+	client   *http.Client
+}
+
+func init() {
+	Register("model_server", func(opts Options, deps Dependencies) (Detector, error) {
+		var cfg ModelServerConfig
+		if err := opts.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("model_server detector: %w", err)
+		}
+		return NewModelServerDetector(cfg, deps.ModelAnalyzer)
+	})
+}
+
+// NewModelServerDetector 创建NLP模型服务检测器，cfg.URL 为空时使用进程内的 analyzer
+func NewModelServerDetector(cfg ModelServerConfig, analyzer ModelAnalyzer) (*ModelServerDetector, error) {
+	cfg = cfg.withDefaults()
+	d := &ModelServerDetector{cfg: cfg}
+	if cfg.URL != "" {
+		d.client = &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Millisecond}
+		return d, nil
+	}
+	if analyzer == nil {
+		return nil, fmt.Errorf("model server url is required when the model server is not running in process")
+	}
+	d.analyzer = analyzer
+	return d, nil
+}
+
+// Detect 分析内容并转换为风险项
+func (d *ModelServerDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
+		return nil, nil
+	}
+
+	req := classifier.AnalyzeRequest{
+		Text:          checkCtx.Content,
+		Contexts:      d.senderHistory(checkCtx),
+		AnalysisTypes: modelServerAnalysisTypes,
+	}
+
+	var analysis *classifier.Analysis
+	var err error
+	if d.analyzer != nil {
+		analysis, err = d.analyzer.Analyze(req)
+	} else {
+		analysis, err = d.analyze(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return d.risks(analysis, checkCtx), nil
+}
+
+// analyze 通过HTTP调用模型服务
+func (d *ModelServerDetector) analyze(ctx context.Context, req classifier.AnalyzeRequest) (*classifier.Analysis, error) {
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal model server request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(d.cfg.URL, "/")+"/analyze", bytes.NewReader(reqData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model server request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send model server request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model server returned non-OK status: %d", resp.StatusCode)
+	}
+
+	var analysis classifier.Analysis
+	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
+		return nil, fmt.Errorf("failed to decode model server response: %w", err)
+	}
+	return &analysis, nil
+}
+
+// senderHistory 返回发送者最近的 context_size 条消息，用于识别重复发送
+func (d *ModelServerDetector) senderHistory(checkCtx *model.CheckContext) []string {
+	var history []string
+	for _, item := range orderedContext(checkCtx.ContextItems) {
+		if item.UserID == checkCtx.UserID {
+			history = append(history, item.Content)
+		}
+	}
+	if len(history) > d.cfg.ContextSize {
+		history = history[len(history)-d.cfg.ContextSize:]
+	}
+	return history
+}
+
+// risks 将分析结果转换为风险项，同一风险类型合并为一项并取最高分
+func (d *ModelServerDetector) risks(analysis *classifier.Analysis, checkCtx *model.CheckContext) []*model.RiskItem {
+	threshold := float64(d.cfg.Threshold)
+	byType := make(map[model.RiskType]*model.RiskItem)
+	var order []model.RiskType
+	add := func(riskType model.RiskType, score float64, description string) *model.RiskItem {
+		riskItem, ok := byType[riskType]
+		if !ok {
+			riskItem = model.NewRiskItem(riskType, float32(score*100), description)
+			riskItem.Details["model_version"] = analysis.ModelVersion
+			byType[riskType] = riskItem
+			order = append(order, riskType)
+		} else if float32(score*100) > riskItem.Score {
+			riskItem.Score = float32(score * 100)
+			riskItem.Description = description
+		}
+		return riskItem
+	}
+
+	// 有害内容：有害概率达到阈值时按概率最高的有害类别输出
+	if t := analysis.Toxicity; t != nil && t.Score >= threshold {
+		category := topCategory(t.Categories)
+		riskItem := add(modelServerRiskType(category), t.Score, fmt.Sprintf("模型判定为有害内容（%s）", category))
+		for name, p := range t.Categories {
+			riskItem.Details["toxicity."+name] = fmt.Sprintf("%.2f", p)
+		}
+	}
+
+	// 意图：侮辱、威胁等风险意图的置信度达到阈值
+	if intent := analysis.Intent; intent != nil && intent.Confidence >= threshold {
+		if riskType, ok := modelServerRiskTypes[intent.Label]; ok {
+			riskItem := add(riskType, intent.Confidence, fmt.Sprintf("模型识别到%s意图", intent.Label))
+			riskItem.Details["intent"] = intent.Label
+			riskItem.Details["intent_confidence"] = fmt.Sprintf("%.2f", intent.Confidence)
+		}
+	}
+
+	// 情感：其他用户已表达拒绝后仍发送强烈负面内容
+	if s := analysis.Sentiment; s != nil && s.Label == classifier.SentimentNegative && s.Intensity >= threshold && d.rejectedByOthers(checkCtx) {
+		riskItem := add(model.RiskTypeContextViolation, s.Intensity, "对方已表达拒绝后仍发送负面内容")
+		riskItem.Details["sentiment"] = fmt.Sprintf("%.2f", s.Score)
+	}
+
+	// 相似度：与发送者近期消息高度重复
+	if sim := analysis.Similarity; sim != nil && len(sim.Scores) > 0 {
+		var repeated int
+		var maxScore float64
+		for _, score := range sim.Scores {
+			if score >= float64(d.cfg.SimilarityThreshold) {
+				repeated++
+			}
+			if score > maxScore {
+				maxScore = score
+			}
+		}
+		if repeated > 0 {
+			riskItem := add(model.RiskTypeSpam, maxScore, fmt.Sprintf("与近期发送的%d条消息高度相似", repeated))
+			riskItem.Details["similarity"] = fmt.Sprintf("%.2f", maxScore)
+		}
+	}
+
+	risks := make([]*model.RiskItem, 0, len(order))
+	for _, riskType := range order {
+		risks = append(risks, byType[riskType])
+	}
+	return risks
+}
+
+// rejectedByOthers 上下文中其他用户是否表达过拒绝
+func (d *ModelServerDetector) rejectedByOthers(checkCtx *model.CheckContext) bool {
+	for _, item := range checkCtx.ContextItems {
+		if item != nil && item.UserID != checkCtx.UserID && containsRejection(item.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelServerRiskType 返回有害类别对应的风险类型
+func modelServerRiskType(category string) model.RiskType {
+	if riskType, ok := modelServerRiskTypes[category]; ok {
+		return riskType
+	}
+	return model.RiskTypeUnknown
+}
+
+// topCategory 返回概率最高的类别，概率相同时取名称靠前的类别
+func topCategory(categories map[string]float64) string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	top := ""
+	for _, name := range names {
+		if top == "" || categories[name] > categories[top] {
+			top = name
+		}
+	}
+	return top
+}
@@ -0,0 +1,120 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ErrInvalidOptions 检测器配置项无效
+var ErrInvalidOptions = errors.New("invalid detector options")
+
+// Options 检测器实例的配置项，由配置文件的 options 原样传入
+type Options map[string]interface{}
+
+// Decode 将配置项解码到带 mapstructure 标签的结构体，字符串形式的数值会自动转换，存在未知配置项时返回ErrInvalidOptions
+func (o Options) Decode(out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           out,
+		WeaklyTypedInput: true,
+		ErrorUnused:      true,
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(map[string]interface{}(o)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOptions, err)
+	}
+	return nil
+}
+
+// Dependencies 检测器可使用的服务内共享组件及实例信息
+type Dependencies struct {
+	SensitiveWords SensitiveWordChecker
+	ModelAnalyzer  ModelAnalyzer // 进程内的NLP模型服务，未在同一进程运行时为nil
+	Instance       string        // 检测器实例名，用于区分同类型实例的统计指标
+}
+
+// Factory 检测器工厂，按实例的配置项创建检测器
+type Factory func(opts Options, deps Dependencies) (Detector, error)
+
+// Registry 检测器类型注册表
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry 创建空的检测器注册表
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// defaultRegistry 内置检测器类型所在的注册表
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry 返回包含全部内置检测器类型的注册表
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register 在默认注册表中注册检测器类型，类型重复时panic，供检测器在init中调用
+func Register(typ string, factory Factory) {
+	if err := defaultRegistry.Register(typ, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Register 注册检测器类型
+func (r *Registry) Register(typ string, factory Factory) error {
+	if typ == "" || factory == nil {
+		return fmt.Errorf("detector type and factory are required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.factories[typ]; ok {
+		return fmt.Errorf("detector type %q already registered", typ)
+	}
+	r.factories[typ] = factory
+	return nil
+}
+
+// Create 按类型创建检测器实例
+func (r *Registry) Create(typ string, opts Options, deps Dependencies) (Detector, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[typ]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown detector type %q", typ)
+	}
+	if opts == nil {
+		opts = Options{}
+	}
+	return factory(opts, deps)
+}
+
+// Has 判断检测器类型是否已注册
+func (r *Registry) Has(typ string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.factories[typ]
+	return ok
+}
+
+// Types 返回已注册的检测器类型，按名称排序
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.factories))
+	for typ := range r.factories {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
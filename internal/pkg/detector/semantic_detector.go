@@ -1,6 +1,7 @@
 package detector
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -36,6 +37,22 @@ type SemanticDetector struct {
 	threshold   float32
 }
 
+// semanticOptions 语义检测器实例配置
+type semanticOptions struct {
+	ContextSize int     `mapstructure:"context_size"`
+	Threshold   float32 `mapstructure:"threshold"`
+}
+
+func init() {
+	Register("semantic", func(opts Options, _ Dependencies) (Detector, error) {
+		o := semanticOptions{Threshold: 0.3}
+		if err := opts.Decode(&o); err != nil {
+			return nil, fmt.Errorf("semantic detector: %w", err)
+		}
+		return NewSemanticDetector(o.ContextSize, o.Threshold), nil
+	})
+}
+
 // NewSemanticDetector 创建语义检测器
 func NewSemanticDetector(contextSize int, threshold float32) *SemanticDetector {
 	return &SemanticDetector{
@@ -45,11 +62,12 @@ func NewSemanticDetector(contextSize int, threshold float32) *SemanticDetector {
 }
 
 // Detect 执行语义分析检测
-func (d *SemanticDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
-	content := ctx.Content
-	if content == "" {
+func (d *SemanticDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
 		return nil, nil
 	}
+	// 不使用 NormalizedContent：其中的分隔字符已被去除，特征会跨越句子边界
+	content := foldText(checkCtx.Content)
 
 	// 风险项列表
 	var risks []*model.RiskItem
@@ -60,7 +78,7 @@ func (d *SemanticDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, e
 	}
 
 	// 2. 基于亲属词的上下文分析
-	if d.familyTermAnalysis(content, ctx.ContextItems, &risks) {
+	if d.familyTermAnalysis(content, checkCtx.ContextItems, &risks) {
 		return risks, nil
 	}
 
@@ -75,8 +93,8 @@ func (d *SemanticDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, e
 	}
 
 	// 4. 上下文对话模式分析
-	if len(ctx.ContextItems) > 0 {
-		d.analyzeConversationPattern(ctx, &risks)
+	if len(checkCtx.ContextItems) > 0 {
+		d.analyzeConversationPattern(checkCtx, &risks)
 	}
 
 	return risks, nil
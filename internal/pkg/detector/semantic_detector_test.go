@@ -0,0 +1,47 @@
+package detector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aa12gq/content-risk-control/internal/app/model"
+	"github.com/aa12gq/content-risk-control/internal/pkg/normalizer"
+)
+
+func TestRegexDetectorsDoNotMatchAcrossSentences(t *testing.T) {
+	n, err := normalizer.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		detector Detector
+		content  string
+		wantRisk bool
+	}{
+		{"semantic threat across comma", NewSemanticDetector(0, 0.3), "之后，果汁很好喝", false},
+		{"semantic threat spaced out", NewSemanticDetector(0, 0.3), "你 等 着 瞧", true},
+		{"semantic fullwidth insult", NewSemanticDetector(0, 0.3), "你这个廢物", true},
+		{"harassment keyword across comma", NewHarassmentDetector(), "不要攻，击他的弱点", false},
+		{"harassment keyword spaced out", NewHarassmentDetector(), "这是 骚 扰", true},
+		{"spam keyword across comma", NewSpamDetector(), "今天免，费午餐", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := n.Normalize(tt.content)
+			checkCtx := &model.CheckContext{
+				Content:           tt.content,
+				NormalizedContent: res.Text,
+				NormalizedOffsets: res.Offsets,
+			}
+			risks, err := tt.detector.Detect(context.Background(), checkCtx)
+			if err != nil {
+				t.Fatalf("Detect: %v", err)
+			}
+			if got := len(risks) > 0; got != tt.wantRisk {
+				t.Errorf("Detect(%q) risks = %+v, want risk %v", tt.content, risks, tt.wantRisk)
+			}
+		})
+	}
+}
@@ -1,15 +1,49 @@
 package detector
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/aa12gq/content-risk-control/internal/app/model"
 )
 
+// 敏感词匹配方式
+const (
+	MatchTypeExact     = "exact"     // 原词命中
+	MatchTypePinyin    = "pinyin"    // 全拼命中（如"sha bi"）
+	MatchTypeInitials  = "initials"  // 拼音首字母命中（如"SB"）
+	MatchTypeHomophone = "homophone" // 同音字或配置的谐音变体命中
+)
+
+// matchTypeWeights 不同匹配方式的分数系数，变体命中的置信度低于原词命中
+var matchTypeWeights = map[string]float32{
+	MatchTypeExact:     1.0,
+	MatchTypeHomophone: 0.85,
+	MatchTypePinyin:    0.8,
+	MatchTypeInitials:  0.6,
+}
+
+// WordMatch 敏感词命中信息，Start/End 为原始内容中的 rune 偏移（左闭右开）
+type WordMatch struct {
+	Word      string
+	Category  string
+	RiskType  model.RiskType
+	Score     float32 // 词条配置的分数，未按匹配方式折算
+	MatchType string
+	Start     int
+	End       int
+	// SuppressedBy 覆盖该命中的豁免短语，仅在解释输出中出现
+	SuppressedBy string
+}
+
 // SensitiveWordChecker 敏感词检查接口
 type SensitiveWordChecker interface {
 	// ContainsWord 检查内容是否包含敏感词
 	ContainsWord(content string) (bool, string)
+	// FindAllWords 返回检查上下文中所有敏感词命中，已按场景及匹配模式过滤
+	FindAllWords(ctx *model.CheckContext) []WordMatch
 }
 
 // SensitiveWordDetector 敏感词检测器
@@ -17,6 +51,15 @@ type SensitiveWordDetector struct {
 	sensitiveWords SensitiveWordChecker
 }
 
+func init() {
+	Register("sensitive", func(_ Options, deps Dependencies) (Detector, error) {
+		if deps.SensitiveWords == nil {
+			return nil, fmt.Errorf("sensitive detector requires a sensitive word checker")
+		}
+		return NewSensitiveWordDetector(deps.SensitiveWords), nil
+	})
+}
+
 // NewSensitiveWordDetector 创建敏感词检测器
 func NewSensitiveWordDetector(sensitiveWords SensitiveWordChecker) *SensitiveWordDetector {
 	return &SensitiveWordDetector{
@@ -24,27 +67,90 @@ func NewSensitiveWordDetector(sensitiveWords SensitiveWordChecker) *SensitiveWor
 	}
 }
 
-// Detect 检测内容是否包含敏感词
-func (d *SensitiveWordDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
-	if ctx.Content == "" {
+// Detect 检测内容是否包含敏感词，每个类别生成一个风险项
+func (d *SensitiveWordDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
 		return nil, nil
 	}
 
-	// 检测是否包含敏感词
-	containsSensitive, word := d.sensitiveWords.ContainsWord(ctx.Content)
-	if !containsSensitive {
+	matches := d.sensitiveWords.FindAllWords(checkCtx)
+	if len(matches) == 0 {
 		return nil, nil
 	}
 
-	// 创建风险项
-	riskItem := &model.RiskItem{
-		Type:        model.RiskTypeSensitiveWord,
-		Score:       80.0, // 默认分数
-		Description: fmt.Sprintf("内容包含敏感词: %s", word),
-		Details: map[string]string{
-			"word": word,
-		},
+	// 按类别分组，保持首次出现的顺序
+	var categories []string
+	grouped := make(map[string][]WordMatch)
+	for _, m := range matches {
+		if _, ok := grouped[m.Category]; !ok {
+			categories = append(categories, m.Category)
+		}
+		grouped[m.Category] = append(grouped[m.Category], m)
+	}
+
+	risks := make([]*model.RiskItem, 0, len(categories))
+	for _, category := range categories {
+		group := grouped[category]
+
+		var words []string
+		var matchTypes []string
+		var score float32
+		riskType := group[0].RiskType
+		seen := make(map[string]bool)
+		seenTypes := make(map[string]bool)
+		positions := make([]string, 0, len(group))
+		matched := make([]string, 0, len(group))
+		for _, m := range group {
+			if !seen[m.Word] {
+				seen[m.Word] = true
+				words = append(words, m.Word)
+			}
+			matchType := m.MatchType
+			if matchType == "" {
+				matchType = MatchTypeExact
+			}
+			if !seenTypes[matchType] {
+				seenTypes[matchType] = true
+				matchTypes = append(matchTypes, matchType)
+			}
+			if itemScore := m.Score * matchTypeWeights[matchType]; itemScore > score {
+				score = itemScore
+				riskType = m.RiskType
+			}
+			positions = append(positions, fmt.Sprintf("%d-%d", m.Start, m.End))
+			matched = append(matched, runeSlice(checkCtx.Content, m.Start, m.End))
+		}
+
+		riskItem := model.NewRiskItem(
+			riskType,
+			score, // 词条分数，变体命中按系数降低
+			fmt.Sprintf("内容包含敏感词: %s", strings.Join(words, "、")),
+		)
+		riskItem.Details["word"] = words[0]
+		riskItem.Details["words"] = strings.Join(words, ",")
+		riskItem.Details["category"] = category
+		riskItem.Details["positions"] = strings.Join(positions, ",")
+		riskItem.Details["matched_text"] = strings.Join(matched, ",")
+		riskItem.Details["hit_count"] = strconv.Itoa(len(group))
+		riskItem.Details["match_type"] = strings.Join(matchTypes, ",")
+
+		risks = append(risks, riskItem)
 	}
 
-	return []*model.RiskItem{riskItem}, nil
+	return risks, nil
+}
+
+// runeSlice 按rune下标截取字符串
+func runeSlice(s string, start, end int) string {
+	runes := []rune(s)
+	if start < 0 {
+		start = 0
+	}
+	if end > len(runes) {
+		end = len(runes)
+	}
+	if start >= end {
+		return ""
+	}
+	return string(runes[start:end])
 }
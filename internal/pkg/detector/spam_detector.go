@@ -1,6 +1,7 @@
 package detector
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -19,19 +20,27 @@ var (
 // SpamDetector 垃圾信息检测器
 type SpamDetector struct{}
 
+func init() {
+	Register("spam", func(Options, Dependencies) (Detector, error) {
+		return NewSpamDetector(), nil
+	})
+}
+
 // NewSpamDetector 创建垃圾信息检测器
 func NewSpamDetector() *SpamDetector {
 	return &SpamDetector{}
 }
 
 // Detect 检测内容是否为垃圾信息
-func (d *SpamDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error) {
-	if ctx.Content == "" {
+func (d *SpamDetector) Detect(ctx context.Context, checkCtx *model.CheckContext) ([]*model.RiskItem, error) {
+	if checkCtx.Content == "" {
 		return nil, nil
 	}
 
-	content := ctx.Content
+	content := checkCtx.Content
 	contentLower := strings.ToLower(content)
+	// 关键词基于折叠后的内容匹配（保留分隔字符，避免跨越句子边界），URL、电话等结构化特征仍基于原始内容
+	normalizedLower := foldText(content)
 	var risks []*model.RiskItem
 
 	// 检测URL密度
@@ -75,7 +84,7 @@ func (d *SpamDetector) Detect(ctx *model.CheckContext) ([]*model.RiskItem, error
 
 	// 检测中文垃圾关键词
 	for _, keyword := range spamKeywordsLower {
-		if strings.Contains(contentLower, keyword) {
+		if strings.Contains(normalizedLower, keyword) {
 			risks = append(risks, &model.RiskItem{
 				Type:        model.RiskTypeSpam,
 				Score:       65.0,
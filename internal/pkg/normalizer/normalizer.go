@@ -0,0 +1,310 @@
+package normalizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 内置归一化阶段名称
+const (
+	StageNFKC      = "nfkc"       // NFKC兼容性折叠（全角转半角、圈字符等）
+	StageLowercase = "lowercase"  // 大小写折叠
+	StageHomoglyph = "homoglyph"  // 形近字符映射（西里尔/希腊字母等）
+	StageT2S       = "t2s"        // 繁体转简体
+	StageZeroWidth = "zero_width" // 去除零宽及格式控制字符
+	StageSeparator = "separator"  // 去除空白、标点、符号等分隔字符，保留拉丁单词间的边界
+)
+
+// DefaultStages 默认归一化阶段及顺序
+var DefaultStages = []string{
+	StageNFKC,
+	StageLowercase,
+	StageHomoglyph,
+	StageT2S,
+	StageZeroWidth,
+	StageSeparator,
+}
+
+// Stage 归一化阶段，逐字符转换
+type Stage interface {
+	// Name 阶段名称
+	Name() string
+	// Transform 转换单个字符，返回nil表示删除该字符
+	Transform(r rune) []rune
+}
+
+// TextStage 需要结合相邻字符处理的阶段，在流水线中的位置作用于整段文本
+type TextStage interface {
+	Stage
+	// TransformText 转换整段文本，offsets为各字符对应的原始rune下标，返回转换后的文本及偏移
+	TransformText(runes []rune, offsets []int) ([]rune, []int)
+}
+
+// Result 归一化结果
+type Result struct {
+	Text string
+	// Offsets 第i个归一化字符对应原始文本中的rune下标
+	Offsets []int
+}
+
+// Normalizer 文本归一化流水线
+type Normalizer struct {
+	stages []Stage
+}
+
+// New 根据阶段名称创建归一化流水线，names为空时使用DefaultStages
+func New(names []string) (*Normalizer, error) {
+	if len(names) == 0 {
+		names = DefaultStages
+	}
+
+	stages := make([]Stage, 0, len(names))
+	for _, name := range names {
+		stage, err := NewStage(name)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, stage)
+	}
+
+	return &Normalizer{stages: stages}, nil
+}
+
+// NewWithStages 使用自定义阶段创建归一化流水线
+func NewWithStages(stages ...Stage) *Normalizer {
+	return &Normalizer{stages: stages}
+}
+
+// NewStage 根据名称创建内置阶段
+func NewStage(name string) (Stage, error) {
+	switch strings.ToLower(name) {
+	case StageNFKC:
+		return nfkcStage{}, nil
+	case StageLowercase:
+		return lowercaseStage{}, nil
+	case StageHomoglyph:
+		return tableStage{name: StageHomoglyph, table: homoglyphTable}, nil
+	case StageT2S:
+		return tableStage{name: StageT2S, table: t2sTable}, nil
+	case StageZeroWidth:
+		return zeroWidthStage{}, nil
+	case StageSeparator:
+		return separatorStage{}, nil
+	default:
+		return nil, fmt.Errorf("unknown normalizer stage: %s", name)
+	}
+}
+
+// Stages 返回阶段名称列表
+func (n *Normalizer) Stages() []string {
+	names := make([]string, 0, len(n.stages))
+	for _, s := range n.stages {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// Normalize 对文本执行归一化并记录偏移映射
+func (n *Normalizer) Normalize(text string) *Result {
+	runes := []rune(text)
+	offsets := make([]int, len(runes))
+	for i := range offsets {
+		offsets[i] = i
+	}
+
+	// 按顺序逐阶段作用于整段文本
+	for _, stage := range n.stages {
+		if ts, ok := stage.(TextStage); ok {
+			runes, offsets = ts.TransformText(runes, offsets)
+			continue
+		}
+
+		next := make([]rune, 0, len(runes))
+		nextOffsets := make([]int, 0, len(offsets))
+		for i, r := range runes {
+			for _, c := range stage.Transform(r) {
+				next = append(next, c)
+				nextOffsets = append(nextOffsets, offsets[i])
+			}
+		}
+		runes, offsets = next, nextOffsets
+	}
+
+	return &Result{
+		Text:    string(runes),
+		Offsets: offsets,
+	}
+}
+
+// String 仅返回归一化后的文本
+func (n *Normalizer) String(text string) string {
+	return n.Normalize(text).Text
+}
+
+// nfkcStage NFKC兼容性折叠
+type nfkcStage struct{}
+
+func (nfkcStage) Name() string { return StageNFKC }
+
+// Transform 逐字符使用时单独折叠每个字符，无法组合基本字符与其后的组合字符
+func (nfkcStage) Transform(r rune) []rune {
+	if r < unicode.MaxASCII {
+		return []rune{r}
+	}
+	return []rune(norm.NFKC.String(string(r)))
+}
+
+// TransformText 按NFKC的规范化片段折叠整段文本，组合字符与基本字符合并（如 "e\u0301" 为 "é"），
+// 片段输出的字符均对应片段第一个字符的偏移
+func (nfkcStage) TransformText(runes []rune, offsets []int) ([]rune, []int) {
+	text := string(runes)
+	if isASCII(runes) || norm.NFKC.IsNormalString(text) {
+		return runes, offsets
+	}
+
+	// 各字符在UTF-8编码中的起始字节
+	starts := make([]int, len(runes))
+	pos := 0
+	for i, r := range runes {
+		starts[i] = pos
+		pos += utf8.RuneLen(r)
+	}
+
+	outRunes := make([]rune, 0, len(runes))
+	outOffsets := make([]int, 0, len(offsets))
+	var it norm.Iter
+	it.InitString(norm.NFKC, text)
+	for !it.Done() {
+		i := sort.SearchInts(starts, it.Pos())
+		for _, r := range string(it.Next()) {
+			outRunes = append(outRunes, r)
+			outOffsets = append(outOffsets, offsets[i])
+		}
+	}
+	return outRunes, outOffsets
+}
+
+// isASCII 判断是否全部为ASCII字符
+func isASCII(runes []rune) bool {
+	for _, r := range runes {
+		if r >= unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// lowercaseStage 大小写折叠
+type lowercaseStage struct{}
+
+func (lowercaseStage) Name() string { return StageLowercase }
+
+func (lowercaseStage) Transform(r rune) []rune {
+	return []rune{unicode.ToLower(r)}
+}
+
+// tableStage 基于映射表的字符替换
+type tableStage struct {
+	name  string
+	table map[rune]rune
+}
+
+func (s tableStage) Name() string { return s.name }
+
+func (s tableStage) Transform(r rune) []rune {
+	if mapped, ok := s.table[r]; ok {
+		return []rune{mapped}
+	}
+	return []rune{r}
+}
+
+// zeroWidthStage 去除零宽及格式控制字符。不去除其余组合字符（Mn），泰文、天城文等的元音符号属于该类别
+type zeroWidthStage struct{}
+
+func (zeroWidthStage) Name() string { return StageZeroWidth }
+
+func (zeroWidthStage) Transform(r rune) []rune {
+	if unicode.Is(unicode.Cf, r) || fillerRunes[r] {
+		return nil
+	}
+	return []rune{r}
+}
+
+// fillerRunes 不属于格式字符但不可见或显示为空白的字符
+var fillerRunes = map[rune]bool{
+	'\u034f': true, // COMBINING GRAPHEME JOINER
+	'\u115f': true, // HANGUL CHOSEONG FILLER
+	'\u1160': true, // HANGUL JUNGSEONG FILLER
+	'\u3164': true, // HANGUL FILLER
+	'\uffa0': true, // HALFWIDTH HANGUL FILLER
+}
+
+// separatorStage 去除空白、标点、符号及控制字符。两侧均为拉丁单词且不都是单个字母时保留一个空格，
+// 避免相邻单词拼接后误命中（如 "this bad" 中的 "sb"），同时仍能还原逐字母拆开的规避写法（如 "f u c k"）
+type separatorStage struct{}
+
+func (separatorStage) Name() string { return StageSeparator }
+
+// Transform 逐字符使用时去除全部分隔字符
+func (separatorStage) Transform(r rune) []rune {
+	if isSeparator(r) {
+		return nil
+	}
+	return []rune{r}
+}
+
+// TransformText 去除分隔字符，拉丁单词之间的分隔合并为一个空格
+func (separatorStage) TransformText(runes []rune, offsets []int) ([]rune, []int) {
+	outRunes := make([]rune, 0, len(runes))
+	outOffsets := make([]int, 0, len(offsets))
+	for i := 0; i < len(runes); {
+		if !isSeparator(runes[i]) {
+			outRunes = append(outRunes, runes[i])
+			outOffsets = append(outOffsets, offsets[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isSeparator(runes[j]) {
+			j++
+		}
+		left, right := latinWordLen(runes[:i], -1), latinWordLen(runes[j:], 1)
+		if left > 0 && right > 0 && (left > 1 || right > 1) {
+			outRunes = append(outRunes, ' ')
+			outOffsets = append(outOffsets, offsets[i])
+		}
+		i = j
+	}
+	return outRunes, outOffsets
+}
+
+// isSeparator 判断是否为空白、标点、符号或控制字符
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsControl(r)
+}
+
+// latinWordLen 返回紧邻片段的拉丁单词长度，dir为-1时从末尾向前计数，为1时从开头向后计数
+func latinWordLen(runes []rune, dir int) int {
+	n := 0
+	if dir < 0 {
+		for i := len(runes) - 1; i >= 0 && isLatinWordRune(runes[i]); i-- {
+			n++
+		}
+		return n
+	}
+	for i := 0; i < len(runes) && isLatinWordRune(runes[i]); i++ {
+		n++
+	}
+	return n
+}
+
+// isLatinWordRune 判断是否为拉丁字母或ASCII数字
+func isLatinWordRune(r rune) bool {
+	return (r >= '0' && r <= '9') || unicode.Is(unicode.Latin, r)
+}
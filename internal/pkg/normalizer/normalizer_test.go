@@ -0,0 +1,108 @@
+package normalizer
+
+import "testing"
+
+func TestSeparatorKeepsLatinWordBoundaries(t *testing.T) {
+	n, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"latin words keep a single space", "this  bad", "this bad"},
+		{"punctuation between latin words", "you, are... sb!", "you are sb"},
+		{"spaced out letters are joined", "f u c k", "fuck"},
+		{"dotted letters are joined", "s.b", "sb"},
+		{"single letter next to a word keeps the boundary", "you are s b", "you are sb"},
+		{"han text drops separators", "你 是 傻 逼", "你是傻逼"},
+		{"han and latin are joined", "你是 sb 啊", "你是sb啊"},
+		{"digits count as latin word runes", "top 10 list", "top 10 list"},
+		{"zero width inside a word is removed", "s​b", "sb"},
+		{"fullwidth space between words", "ｈｅｌｌｏ　ｗｏｒｌｄ", "hello world"},
+		{"leading and trailing separators", "  hello!  ", "hello"},
+		{"first rune removed by an earlier stage", "​hi there", "hi there"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.String(tt.in); got != tt.want {
+				t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeparatorOffsets(t *testing.T) {
+	n, err := New([]string{StageSeparator})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := n.Normalize("ab, cd")
+	if res.Text != "ab cd" {
+		t.Fatalf("Text = %q, want %q", res.Text, "ab cd")
+	}
+	want := []int{0, 1, 2, 4, 5}
+	if len(res.Offsets) != len(want) {
+		t.Fatalf("Offsets = %v, want %v", res.Offsets, want)
+	}
+	for i := range want {
+		if res.Offsets[i] != want[i] {
+			t.Fatalf("Offsets = %v, want %v", res.Offsets, want)
+		}
+	}
+}
+
+func TestFoldStages(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []string
+		in     string
+		want   string
+	}{
+		{"nfkc composes combining marks", []string{StageNFKC}, "cafe\u0301", "caf\u00e9"},
+		{"nfkc folds fullwidth and circled", []string{StageNFKC}, "ＡＢＣ①", "ABC1"},
+		{"nfkc composes hangul jamo", []string{StageNFKC}, "\u1100\u1161", "\uac00"},
+		{"zero width keeps thai vowel signs", []string{StageZeroWidth}, "กิน", "กิน"},
+		{"zero width keeps devanagari signs", []string{StageZeroWidth}, "हिंदी", "हिंदी"},
+		{"zero width removes format chars", []string{StageZeroWidth}, "a\u200bb\u200dc\ufeffd", "abcd"},
+		{"zero width removes fillers", []string{StageZeroWidth}, "a\u3164b\u034fc", "abc"},
+		{"combining mark composed before zero width", []string{StageNFKC, StageZeroWidth}, "e\u0301", "\u00e9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(tt.stages)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := n.String(tt.in); got != tt.want {
+				t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNFKCOffsets(t *testing.T) {
+	n, err := New([]string{StageNFKC})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "é" 由原文第1、2个字符组合而成，"①" 折叠为 "1"
+	res := n.Normalize("xe\u0301\u2460")
+	if res.Text != "x\u00e91" {
+		t.Fatalf("Text = %q, want %q", res.Text, "x\u00e91")
+	}
+	want := []int{0, 1, 3}
+	if len(res.Offsets) != len(want) {
+		t.Fatalf("Offsets = %v, want %v", res.Offsets, want)
+	}
+	for i := range want {
+		if res.Offsets[i] != want[i] {
+			t.Fatalf("Offsets = %v, want %v", res.Offsets, want)
+		}
+	}
+}
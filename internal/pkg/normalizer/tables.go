@@ -0,0 +1,78 @@
+package normalizer
+
+// t2sTraditional/t2sSimplified 常用繁体字与对应简体字，按位置一一对应
+const (
+	t2sTraditional = "" +
+		"萬與專業東絲兩嚴喪個豐臨為麗舉義烏樂喬習鄉書買亂爭於虧雲亞產畝親億僅從侖倉儀們價" +
+		"眾優會傘偉傳傷倫偽體餘傭僉俠侶僥偵側僑儈儂俁儔儼倆儷儉債傾僂僨償儲兒兌黨蘭關興養" +
+		"獸內岡冊寫軍農馮衝決況凍淨涼減湊凜幾鳳憑凱擊鑿芻劃劉則剛創刪別剎劑剮劍剝劇勸辦務" +
+		"動勵勁勞勢勛勻匯區醫華協單賣盧衛卻廠廳歷厲壓厭縣參雙發變敘疊葉號嘆嘰嚇呂嗎噸聽啟" +
+		"吳嘔員響啞問喚嘯噴嚨團園圍圓圖國場壞塊堅壇墳墜壟壘墾執報聲殼處備復夠頭誇夾奪奮獎" +
+		"婦媽嫵嬌孫學寧寶實寵審憲宮對尋導將層屬歲島嶺嶽峽幣帥師帳帶幫廣莊慶廬應廟廢開異棄" +
+		"張彌彎彈強歸當錄徹徑憶懷態憐總戀惡惱悅懸驚懼慘慚憫懲懶戲戰戶撲擴掃揚擾撫搶護擔擬" +
+		"擁擇掛擋擠揮撈損撿換據擄攜搖攝擺攤撐敵數齋斷無舊時曠晝顯曉暫術機殺雜權條來楊極構" +
+		"槍樞標棧欄樹樣橋檢櫃歡歐殘殲毀氣漢湯溝沒滄淚潑潔灑濃濤澇漲淵漁溫灣濕潰濺滅燈災爐" +
+		"點煉爛熱煩燒營愛爺牆狀猶獨獄貓獻環現瑣電畫暢療瘋癢盡監盤盜睜瞞礎確碼礙禮禍離種積" +
+		"稱穩窮竊競筆築簡節範籠類糧緊紅約級紀純紙紛細終組經結給絡絕統綠維網線練編緣織繪繼" +
+		"續罰羅聯聰職膽脫腦臟艦藝蘇莖薦藥蟲蝦螞蠶補裝襲見規視覽覺觀訂計認討讓訓議記講論設" +
+		"訪證評識詐訴診詞試話該詳誤說請諸讀課誰調談謝謠謀謊謎貝負貢財責賢敗貨質販貪貧購貯" +
+		"貫貸費貿賀資賊賭賬賠賴賺賽贈贊贏趕趙躍蹤車軌軟轉輪輕較輸轟辭邊遼達遷過邁運還這進" +
+		"遠違連遲適選遺鄰鄭醜釋針釣鈔鋼錢鐵鈴鉛銀銷鋒鎖鏡鐘錯錘鍵長門閃閉間閒閱闊隊陽陰陣" +
+		"階際陸陳險隨隱難雞霧靈靜韋韓頁頂項順須預領頻顆題額顏願顧風飛飯飲飽餓館馬駕駛驗騙" +
+		"騷驅驢鬥鬧魚鮮鳥鳴鴨鵝鹽麥黃齊齒龍龜黴穢詛屍賤滾豬癡緬鹹傑禦麵鬆髮製採噁蘋籤暈麼" +
+		"週裏衆贓槓跡賓聖蠻擲濫罷飾肅嗚燦憤紋殯軀繩檔糞鍋碩慣摯灘誠謹蕩頓頸鑽鏈賦賜閣綁兇" +
+		"屆慾鎮曬畢嶄撥飄揀誌懇襯鬱緒傢嘗匱勳廁歎"
+
+	t2sSimplified = "" +
+		"万与专业东丝两严丧个丰临为丽举义乌乐乔习乡书买乱争于亏云亚产亩亲亿仅从仑仓仪们价" +
+		"众优会伞伟传伤伦伪体余佣佥侠侣侥侦侧侨侩侬俣俦俨俩俪俭债倾偻偾偿储儿兑党兰关兴养" +
+		"兽内冈册写军农冯冲决况冻净凉减凑凛几凤凭凯击凿刍划刘则刚创删别刹剂剐剑剥剧劝办务" +
+		"动励劲劳势勋匀汇区医华协单卖卢卫却厂厅历厉压厌县参双发变叙叠叶号叹叽吓吕吗吨听启" +
+		"吴呕员响哑问唤啸喷咙团园围圆图国场坏块坚坛坟坠垄垒垦执报声壳处备复够头夸夹夺奋奖" +
+		"妇妈妩娇孙学宁宝实宠审宪宫对寻导将层属岁岛岭岳峡币帅师帐带帮广庄庆庐应庙废开异弃" +
+		"张弥弯弹强归当录彻径忆怀态怜总恋恶恼悦悬惊惧惨惭悯惩懒戏战户扑扩扫扬扰抚抢护担拟" +
+		"拥择挂挡挤挥捞损捡换据掳携摇摄摆摊撑敌数斋断无旧时旷昼显晓暂术机杀杂权条来杨极构" +
+		"枪枢标栈栏树样桥检柜欢欧残歼毁气汉汤沟没沧泪泼洁洒浓涛涝涨渊渔温湾湿溃溅灭灯灾炉" +
+		"点炼烂热烦烧营爱爷墙状犹独狱猫献环现琐电画畅疗疯痒尽监盘盗睁瞒础确码碍礼祸离种积" +
+		"称稳穷窃竞笔筑简节范笼类粮紧红约级纪纯纸纷细终组经结给络绝统绿维网线练编缘织绘继" +
+		"续罚罗联聪职胆脱脑脏舰艺苏茎荐药虫虾蚂蚕补装袭见规视览觉观订计认讨让训议记讲论设" +
+		"访证评识诈诉诊词试话该详误说请诸读课谁调谈谢谣谋谎谜贝负贡财责贤败货质贩贪贫购贮" +
+		"贯贷费贸贺资贼赌账赔赖赚赛赠赞赢赶赵跃踪车轨软转轮轻较输轰辞边辽达迁过迈运还这进" +
+		"远违连迟适选遗邻郑丑释针钓钞钢钱铁铃铅银销锋锁镜钟错锤键长门闪闭间闲阅阔队阳阴阵" +
+		"阶际陆陈险随隐难鸡雾灵静韦韩页顶项顺须预领频颗题额颜愿顾风飞饭饮饱饿馆马驾驶验骗" +
+		"骚驱驴斗闹鱼鲜鸟鸣鸭鹅盐麦黄齐齿龙龟霉秽诅尸贱滚猪痴缅咸杰御面松发制采恶苹签晕么" +
+		"周里众赃杠迹宾圣蛮掷滥罢饰肃呜灿愤纹殡躯绳档粪锅硕惯挚滩诚谨荡顿颈钻链赋赐阁绑凶" +
+		"届欲镇晒毕崭拨飘拣志恳衬郁绪家尝匮勋厕叹"
+)
+
+// t2sTable 繁体到简体的映射表
+var t2sTable = buildRuneTable(t2sTraditional, t2sSimplified)
+
+// homoglyphTable 与拉丁字母形近的西里尔、希腊等字符映射表（已完成大小写折叠）
+var homoglyphTable = map[rune]rune{
+	// 西里尔字母
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's',
+	'і': 'i', 'ї': 'i', 'ј': 'j', 'һ': 'h', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'ь': 'b', 'п': 'n', 'г': 'r',
+	// 希腊字母
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w', 'γ': 'y',
+	// 其他常见形近字符
+	'ı': 'i', 'ł': 'l', 'ø': 'o', 'đ': 'd', 'ħ': 'h', 'ŀ': 'l',
+}
+
+// buildRuneTable 根据两个等长字符串构建逐字映射表
+func buildRuneTable(from, to string) map[rune]rune {
+	src := []rune(from)
+	dst := []rune(to)
+	if len(src) != len(dst) {
+		panic("normalizer: mapping table length mismatch")
+	}
+
+	table := make(map[rune]rune, len(src))
+	for i, r := range src {
+		table[r] = dst[i]
+	}
+	return table
+}
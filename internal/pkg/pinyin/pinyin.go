@@ -0,0 +1,213 @@
+package pinyin
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed pinyin.txt
+var tableData string
+
+var (
+	// charTable 汉字 -> 无声调拼音
+	charTable map[rune]string
+	// syllables 全部合法拼音音节
+	syllables map[string]bool
+	// maxSyllableLen 最长音节长度
+	maxSyllableLen int
+)
+
+func init() {
+	charTable = make(map[rune]string)
+	syllables = make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(tableData))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		syllable := fields[0]
+		syllables[syllable] = true
+		if len(syllable) > maxSyllableLen {
+			maxSyllableLen = len(syllable)
+		}
+
+		for _, chars := range fields[1:] {
+			for _, r := range chars {
+				// 多音字以首次出现的读音为准
+				if _, ok := charTable[r]; !ok {
+					charTable[r] = syllable
+				}
+			}
+		}
+	}
+}
+
+// Lookup 返回汉字的无声调拼音
+func Lookup(r rune) (string, bool) {
+	py, ok := charTable[r]
+	return py, ok
+}
+
+// IsSyllable 判断是否为合法拼音音节
+func IsSyllable(s string) bool {
+	return syllables[s]
+}
+
+// Syllables 将文本转换为拼音音节列表，任一字符无法转换时返回false
+func Syllables(text string) ([]string, bool) {
+	result := make([]string, 0, len(text)/3)
+	for _, r := range text {
+		py, ok := charTable[r]
+		if !ok {
+			return nil, false
+		}
+		result = append(result, py)
+	}
+	return result, true
+}
+
+// Full 返回文本的全拼（如"傻逼" -> "shabi"）
+func Full(text string) (string, bool) {
+	parts, ok := Syllables(text)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(parts, ""), true
+}
+
+// Initials 返回文本的拼音首字母（如"傻逼" -> "sb"）
+func Initials(text string) (string, bool) {
+	parts, ok := Syllables(text)
+	if !ok {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for _, p := range parts {
+		sb.WriteByte(p[0])
+	}
+	return sb.String(), true
+}
+
+// Segment 将连续的拉丁字母按最长匹配切分为拼音音节，
+// 返回每个音节的结束下标（字节偏移）；无法完整切分时返回false
+func Segment(letters string) ([]int, bool) {
+	var ends []int
+	pos := 0
+	for pos < len(letters) {
+		matched := 0
+		for l := maxSyllableLen; l > 0; l-- {
+			if pos+l <= len(letters) && syllables[letters[pos:pos+l]] {
+				matched = l
+				break
+			}
+		}
+		if matched == 0 {
+			return nil, false
+		}
+		pos += matched
+		ends = append(ends, pos)
+	}
+	return ends, true
+}
+
+// Stream 文本的拼音转写结果，仅包含小写ASCII字母和分隔空格
+type Stream struct {
+	Text string
+	// Offsets 转写文本第i个字节对应原始文本的rune下标
+	Offsets []int
+	// Boundaries 转写文本中位置i（0..len(Text)）是否为音节边界
+	Boundaries []bool
+}
+
+// Transliterate 将文本转写为拼音流：汉字替换为拼音，连续字母按音节切分，
+// 其他字符转为空格以阻断跨越匹配
+func Transliterate(text string) *Stream {
+	var sb strings.Builder
+	var offsets []int
+	var boundaries []bool
+
+	mark := func() {
+		for len(boundaries) <= sb.Len() {
+			boundaries = append(boundaries, false)
+		}
+		boundaries[sb.Len()] = true
+	}
+	write := func(s string, idx int) {
+		sb.WriteString(s)
+		for i := 0; i < len(s); i++ {
+			offsets = append(offsets, idx)
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// 连续拉丁字母
+		if isLetter(r) {
+			j := i
+			for j < len(runes) && isLetter(runes[j]) {
+				j++
+			}
+			letters := strings.ToLower(string(runes[i:j]))
+
+			mark()
+			start := sb.Len()
+			for k := 0; k < len(letters); k++ {
+				sb.WriteByte(letters[k])
+				offsets = append(offsets, i+k)
+			}
+			if ends, ok := Segment(letters); ok {
+				for _, end := range ends {
+					for len(boundaries) <= start+end {
+						boundaries = append(boundaries, false)
+					}
+					boundaries[start+end] = true
+				}
+			}
+			mark()
+			i = j
+			continue
+		}
+
+		if py, ok := charTable[r]; ok {
+			mark()
+			write(py, i)
+			mark()
+		} else {
+			write(" ", i)
+		}
+		i++
+	}
+
+	for len(boundaries) <= sb.Len() {
+		boundaries = append(boundaries, false)
+	}
+
+	return &Stream{
+		Text:       sb.String(),
+		Offsets:    offsets,
+		Boundaries: boundaries,
+	}
+}
+
+// isLetter 判断是否为ASCII字母
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// IsHan 判断字符是否为拼音表中收录的汉字
+func IsHan(r rune) bool {
+	_, ok := charTable[r]
+	return ok
+}
@@ -0,0 +1,29 @@
+#!/bin/bash
+# 提示词注入回归测试：逐条提交 injection_samples.txt 中的样本，检查是否输出 suspicious_behavior 风险
+# 用法: ./test/injection_test.sh [服务地址]，需启用 injection 检测器
+
+SERVER=${1:-http://localhost:8080}
+SAMPLES="$(dirname "$0")/injection_samples.txt"
+SUSPICIOUS_BEHAVIOR=8
+
+total=0
+failed=0
+while IFS=$'\t' read -r expect content; do
+  case "$expect" in
+    ""|\#*) continue ;;
+  esac
+  total=$((total + 1))
+
+  flagged=$(jq -n --arg content "$content" '{content: $content, user_id: "injection_test", scene: "comment"}' |
+    curl -s -X POST "$SERVER/api/v1/check" -H "Content-Type: application/json" -d @- |
+    jq --argjson type "$SUSPICIOUS_BEHAVIOR" '[.risks[]? | select(.type == $type)] | length > 0')
+
+  if { [ "$expect" = "injection" ] && [ "$flagged" != "true" ]; } ||
+    { [ "$expect" = "benign" ] && [ "$flagged" != "false" ]; }; then
+    failed=$((failed + 1))
+    echo "FAIL [$expect] $content"
+  fi
+done < "$SAMPLES"
+
+echo "样本 $total 条，失败 $failed 条"
+[ "$failed" -eq 0 ]